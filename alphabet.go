@@ -0,0 +1,233 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Comparison is how the cells of matrixes with more than two levels are
+// compared. The cells of binary matrixes are equal or different with any
+// comparison
+type Comparison int
+
+// The comparisons of the cells
+const (
+	// CompareExact compares the symbols, a cell matches only if both cells
+	// have the same level
+	CompareExact Comparison = iota
+	// CompareDistance compares the levels, a cell matches in proportion to
+	// the distance of both levels, fully if they are the same level and not at
+	// all if they are the first and the last levels. For matrixes of values
+	// the agreement is 1 minus the normalized sum of absolute differences
+	CompareDistance
+	// CompareTolerance is like CompareExact but a cell matches if the
+	// difference of both values, or levels, is not higher than the tolerance
+	CompareTolerance
+)
+
+// Comparisons is the list of all the comparisons of the cells
+var Comparisons = []Comparison{CompareExact, CompareDistance, CompareTolerance}
+
+var comparisonNames = []string{"exact", "distance", "tolerance"}
+
+func (c Comparison) String() string {
+	if c < 0 || int(c) >= len(comparisonNames) {
+		return fmt.Sprintf("Comparison(%d)", int(c))
+	}
+	return comparisonNames[c]
+}
+
+// ParseComparison returns the comparison with the given name, an empty name is
+// the default comparison `CompareExact`
+func ParseComparison(name string) (Comparison, error) {
+	if len(name) == 0 {
+		return CompareExact, nil
+	}
+	for i, n := range comparisonNames {
+		if n == name {
+			return Comparison(i), nil
+		}
+	}
+	return CompareExact, fmt.Errorf("unknown comparison %q. Available comparisons are: %s", name, strings.Join(comparisonNames, ", "))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (c Comparison) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (c *Comparison) UnmarshalText(text []byte) error {
+	v, err := ParseComparison(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// checkAlphabet returns an error if the alphabet is not UTF-8 text with at
+// least two symbols, or if it has a repeated symbol, a new line or the ignore
+// symbol
+func checkAlphabet(alphabet string, ignore rune) error {
+	if !utf8.ValidString(alphabet) {
+		return fmt.Errorf("alphabet %q is not valid UTF-8 text", alphabet)
+	}
+	symbols := []rune(alphabet)
+	if len(symbols) < 2 {
+		return fmt.Errorf("alphabet %q has to have at least two symbols", alphabet)
+	}
+	for i, c := range symbols {
+		switch {
+		case c == '\n':
+			return fmt.Errorf("alphabet %q cannot have a new line", alphabet)
+		case ignore != 0 && c == ignore:
+			return fmt.Errorf("alphabet %q cannot have the ignore symbol %q", alphabet, ignore)
+		case strings.ContainsRune(string(symbols[:i]), c):
+			return fmt.Errorf("alphabet %q has the symbol %q repeated", alphabet, c)
+		}
+	}
+	return nil
+}
+
+// alphabetSymbols returns the symbols table of the alphabet, every symbol is
+// the level of its position and `ignore`, if not `0`, is an ignored cell
+func alphabetSymbols(alphabet string, ignore rune) symbols {
+	s := symbols{}
+	if ignore != 0 {
+		s[ignore] = Ignored
+	}
+	for i, c := range []rune(alphabet) {
+		s[c] = i
+	}
+	return s
+}
+
+// LoadMatrixWithAlphabet creates a matrix from a reader with the given alphabet
+func LoadMatrixWithAlphabet(r io.Reader, alphabet string, ignore rune) (*Matrix, error) {
+	m := &Matrix{}
+	err := m.LoadWithAlphabet(r, alphabet, ignore)
+	return m, err
+}
+
+// LoadWithAlphabet loads a matrix from a reader replacing every symbol of the
+// alphabet for its level, the first symbol is the level `0`, and the value
+// given in `ignore`, if not `0`, for `Ignored`. A two symbols alphabet is the
+// same as Load with the symbols `zero` and `one`
+func (m *Matrix) LoadWithAlphabet(r io.Reader, alphabet string, ignore rune) error {
+	if err := checkAlphabet(alphabet, ignore); err != nil {
+		return err
+	}
+	return m.load(r, alphabetSymbols(alphabet, ignore), utf8.RuneCountInString(alphabet))
+}
+
+// Levels returns the number of levels of the cells, the cells not ignored are
+// from `0` to `Levels() - 1`. A binary matrix has 2 levels
+func (m *Matrix) Levels() int {
+	if m.levels < 2 {
+		return 2
+	}
+	return m.levels
+}
+
+// binary returns true if the cells are zeros and ones, so the bit packed rows
+// have all the matrix content
+func (m *Matrix) binary() bool {
+	return m.Levels() == 2
+}
+
+// SprintfWithAlphabet returns a string representing the matrix using the
+// symbol of the alphabet for every level and the given `ignore` string to
+// represent the ignored cells. The levels without a symbol are `?`
+func (m *Matrix) SprintfWithAlphabet(alphabet, ignore string) string {
+	symbols := []rune(alphabet)
+	var b bytes.Buffer
+	for y := range m.bits {
+		for x := 0; x < m.maxX; x++ {
+			switch v := m.Cell(x, y); {
+			case v == Ignored:
+				b.WriteString(ignore)
+			case v >= 0 && v < len(symbols):
+				b.WriteRune(symbols[v])
+			default:
+				b.WriteRune('?')
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// confusionCellsAt is like confusionAt but comparing every cell, for matrixes
+// with more than two levels or values. The values, or levels, are normalized
+// in the range of both matrixes and they are fuzzy ones, a normalized value
+// `v` is `v` of being a one and the rest of being a zero, so with
+// `CompareDistance` two cells are partially TP, TN, FP and FN. With
+// `CompareExact` two equal cells are a TN if it's the lowest value or a TP
+// otherwise, and two different cells are a FP if the source value is higher
+// or a FN otherwise. `CompareTolerance` is like `CompareExact` with the
+// parameters tolerance
+func (m *Matrix) confusionCellsAt(x, y int, target *Matrix, params SearchParams) Confusion {
+	lo, hi := m.valueRange()
+	if tlo, thi := target.valueRange(); tlo < lo || thi > hi {
+		lo, hi = math.Min(lo, tlo), math.Max(hi, thi)
+	}
+	span := hi - lo
+	if span == 0 {
+		span = 1
+	}
+	var tolerance float64
+	if params.Comparison == CompareTolerance {
+		tolerance = params.Tolerance
+	}
+
+	var c Confusion
+	for yi := range target.bits {
+		for xi := 0; xi < target.maxX; xi++ {
+			if m.Cell(x+xi, y+yi) == Ignored || target.Cell(xi, yi) == Ignored {
+				continue
+			}
+			s, t := m.value(x+xi, y+yi), target.value(xi, yi)
+			w := target.weight(xi, yi)
+			if params.Comparison == CompareDistance {
+				a, b := (s-lo)/span, (t-lo)/span
+				c.TP += w * math.Min(a, b)
+				c.TN += w * math.Min(1-a, 1-b)
+				c.FP += w * math.Max(0, a-b)
+				c.FN += w * math.Max(0, b-a)
+				continue
+			}
+			switch equal := math.Abs(s-t) <= tolerance; {
+			case equal && t-lo <= tolerance:
+				c.TN += w
+			case equal:
+				c.TP += w
+			case s > t:
+				c.FP += w
+			default:
+				c.FN += w
+			}
+		}
+	}
+	return c
+}
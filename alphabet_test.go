@@ -0,0 +1,154 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+const testShades = " .:-=+*#%@"
+
+func TestMatrix_LoadWithAlphabet(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+		content  string
+		want     [][]int
+		wantErr  bool
+	}{
+		{"shades", testShades, " .:\n#%@\n", [][]int{{0, 1, 2}, {7, 8, 9}}, false},
+		{"ignored", testShades, "?@\n.?\n", [][]int{{Ignored, 9}, {1, Ignored}}, false},
+		{"binary", " +", "+ \n +\n", [][]int{{1, 0}, {0, 1}}, false},
+		{"runes", " ░▒▓█", "█▓▒\n░ ?\n", [][]int{{4, 3, 2}, {1, 0, Ignored}}, false},
+		{"invalid value", testShades, " x\n..\n", nil, true},
+		{"one symbol", "+", "++\n++\n", nil, true},
+		{"repeated symbol", "+-+", "+-\n-+\n", nil, true},
+		{"ignore symbol", " ?+", " ?\n+ \n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := LoadMatrixWithAlphabet(bytes.NewBufferString(tt.content), tt.alphabet, '?')
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMatrixWithAlphabet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(m.Content(), tt.want) {
+				t.Errorf("LoadMatrixWithAlphabet() content = %v, want %v", m.Content(), tt.want)
+			}
+			if got, want := m.Levels(), len([]rune(tt.alphabet)); got != want {
+				t.Errorf("Matrix.Levels() = %d, want %d", got, want)
+			}
+			if got := m.SprintfWithAlphabet(tt.alphabet, "?"); got != tt.content {
+				t.Errorf("Matrix.SprintfWithAlphabet() = %q, want %q", got, tt.content)
+			}
+		})
+	}
+}
+
+func TestMatrix_ConfusionWith(t *testing.T) {
+	load := func(content string) *Matrix {
+		m, err := LoadMatrixWithAlphabet(bytes.NewBufferString(content), testShades, '?')
+		if err != nil {
+			t.Fatalf("failed to load the matrix. %s", err)
+		}
+		return m
+	}
+	tests := []struct {
+		name    string
+		m       *Matrix
+		m1      *Matrix
+		cmp     Comparison
+		want    Confusion
+		wantErr bool
+	}{
+		{"exact equal", load(" @\n+?\n"), load(" @\n++\n"), CompareExact, Confusion{TP: 2, TN: 1}, false},
+		{"exact different", load(" @\n.:\n"), load(".#\n: \n"), CompareExact, Confusion{FP: 2, FN: 2}, false},
+		{"distance equal", load(" @\n+?\n"), load(" @\n++\n"), CompareDistance, Confusion{TP: 1 + 5.0/9, TN: 1 + 4.0/9}, false},
+		{"distance opposite", load(" @\n"), load("@ \n"), CompareDistance, Confusion{FP: 1, FN: 1}, false},
+		{"distance near", load("*\n"), load("=\n"), CompareDistance, Confusion{TP: 4.0 / 9, TN: 3.0 / 9, FP: 2.0 / 9}, false},
+		{"different size", load(" @\n"), load(" \n"), CompareExact, Confusion{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.ConfusionWith(tt.m1, SearchParams{Comparison: tt.cmp})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Matrix.ConfusionWith() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !equalConfusion(got, tt.want) {
+				t.Errorf("Matrix.ConfusionWith() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// equalConfusion returns true if both confusion counts are equal up to the
+// floating point rounding
+func equalConfusion(c, c1 Confusion) bool {
+	return math.Abs(c.TP-c1.TP) < 1e-9 && math.Abs(c.TN-c1.TN) < 1e-9 && math.Abs(c.FP-c1.FP) < 1e-9 && math.Abs(c.FN-c1.FN) < 1e-9
+}
+
+func TestFinder2D_Search_alphabet(t *testing.T) {
+	source := "@@@@@@@@\n@ .:@:-=\n@-=+@+*#\n@@@@@@@@\n"
+	target := " .:\n-=+\n"
+	tests := []struct {
+		name       string
+		comparison Comparison
+		percentage float64
+		want       []Match
+	}{
+		{"exact", CompareExact, 100, []Match{{X: 1, Y: 1, Percentage: 100, Width: 3, Height: 2}}},
+		// the darker copy is 2 levels away in every cell
+		{"distance", CompareDistance, 75, []Match{{X: 1, Y: 1, Percentage: 100, Width: 3, Height: 2}, {X: 5, Y: 1, Percentage: 100 - 200.0/9, Width: 3, Height: 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(0, 0, tt.percentage, 1, WithAlphabet(testShades))
+			if err := f.LoadSource(bytes.NewBufferString(source)); err != nil {
+				t.Fatalf("Finder2D.LoadSource() error = %v", err)
+			}
+			if err := f.LoadTarget(bytes.NewBufferString(target)); err != nil {
+				t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+			}
+			if got := f.Sprintf(f.Target); got != target {
+				t.Errorf("Finder2D.Sprintf() = %q, want %q", got, target)
+			}
+			f.Comparison = tt.comparison
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			if len(f.Matches) != len(tt.want) {
+				t.Fatalf("Finder2D.Search() = %v, want %v", f.Matches, tt.want)
+			}
+			for i, m := range f.Matches {
+				want := tt.want[i]
+				if m.X != want.X || m.Y != want.Y || math.Abs(m.Percentage-want.Percentage) > 1e-9 {
+					t.Errorf("Finder2D.Search() match #%d = %v, want %v", i, m, want)
+				}
+			}
+		})
+	}
+
+	f := New(0, 0, 0, 0)
+	if err := f.SetAlphabet("+"); err == nil {
+		t.Errorf("Finder2D.SetAlphabet(\"+\") expected an error")
+	}
+}
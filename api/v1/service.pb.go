@@ -11,6 +11,7 @@ import (
 	proto "github.com/golang/protobuf/proto"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -111,6 +112,13 @@ type Match struct {
 	X                    int32    `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
 	Y                    int32    `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
 	Percentage           float32  `protobuf:"fixed32,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Orientation          string   `protobuf:"bytes,4,opt,name=orientation,proto3" json:"orientation,omitempty"`
+	Width                int32    `protobuf:"varint,5,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32    `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	Scale                float32  `protobuf:"fixed32,7,opt,name=scale,proto3" json:"scale,omitempty"`
+	Target               string   `protobuf:"bytes,8,opt,name=target,proto3" json:"target,omitempty"`
+	PValue               float64  `protobuf:"fixed64,9,opt,name=p_value,json=pValue,proto3" json:"p_value,omitempty"`
+	Visible              float32  `protobuf:"fixed32,10,opt,name=visible,proto3" json:"visible,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -162,6 +170,55 @@ func (m *Match) GetPercentage() float32 {
 	return 0
 }
 
+func (m *Match) GetOrientation() string {
+	if m != nil {
+		return m.Orientation
+	}
+	return ""
+}
+
+func (m *Match) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *Match) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Match) GetScale() float32 {
+	if m != nil {
+		return m.Scale
+	}
+	return 0
+}
+
+func (m *Match) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *Match) GetPValue() float64 {
+	if m != nil {
+		return m.PValue
+	}
+	return 0
+}
+
+func (m *Match) GetVisible() float32 {
+	if m != nil {
+		return m.Visible
+	}
+	return 0
+}
+
 type GetMatrixRequest struct {
 	Api                  string     `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	Name                 MatrixName `protobuf:"varint,2,opt,name=name,proto3,enum=finder2d.v1.MatrixName" json:"name,omitempty"`
@@ -268,6 +325,9 @@ type LoadMatrixRequest struct {
 	Api                  string     `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	Name                 MatrixName `protobuf:"varint,2,opt,name=name,proto3,enum=finder2d.v1.MatrixName" json:"name,omitempty"`
 	Matrix               *Matrix    `protobuf:"bytes,3,opt,name=matrix,proto3" json:"matrix,omitempty"`
+	Alphabet             string     `protobuf:"bytes,4,opt,name=alphabet,proto3" json:"alphabet,omitempty"`
+	Data                 []byte     `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	Threshold            string     `protobuf:"bytes,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
@@ -319,6 +379,27 @@ func (m *LoadMatrixRequest) GetMatrix() *Matrix {
 	return nil
 }
 
+func (m *LoadMatrixRequest) GetAlphabet() string {
+	if m != nil {
+		return m.Alphabet
+	}
+	return ""
+}
+
+func (m *LoadMatrixRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *LoadMatrixRequest) GetThreshold() string {
+	if m != nil {
+		return m.Threshold
+	}
+	return ""
+}
+
 type LoadMatrixResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -358,378 +439,1228 @@ func (m *LoadMatrixResponse) GetApi() string {
 	return ""
 }
 
-type SearchRequest struct {
-	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Percentage           float32  `protobuf:"fixed32,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
-	Delta                int32    `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+type Target struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Width                int32    `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32    `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
-func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
-func (*SearchRequest) ProtoMessage()    {}
-func (*SearchRequest) Descriptor() ([]byte, []int) {
+func (m *Target) Reset()         { *m = Target{} }
+func (m *Target) String() string { return proto.CompactTextString(m) }
+func (*Target) ProtoMessage()    {}
+func (*Target) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{6}
 }
 
-func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+func (m *Target) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Target.Unmarshal(m, b)
 }
-func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+func (m *Target) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Target.Marshal(b, m, deterministic)
 }
-func (m *SearchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SearchRequest.Merge(m, src)
+func (m *Target) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Target.Merge(m, src)
 }
-func (m *SearchRequest) XXX_Size() int {
-	return xxx_messageInfo_SearchRequest.Size(m)
+func (m *Target) XXX_Size() int {
+	return xxx_messageInfo_Target.Size(m)
 }
-func (m *SearchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+func (m *Target) XXX_DiscardUnknown() {
+	xxx_messageInfo_Target.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+var xxx_messageInfo_Target proto.InternalMessageInfo
 
-func (m *SearchRequest) GetApi() string {
+func (m *Target) GetName() string {
 	if m != nil {
-		return m.Api
+		return m.Name
 	}
 	return ""
 }
 
-func (m *SearchRequest) GetPercentage() float32 {
+func (m *Target) GetWidth() int32 {
 	if m != nil {
-		return m.Percentage
+		return m.Width
 	}
 	return 0
 }
 
-func (m *SearchRequest) GetDelta() int32 {
+func (m *Target) GetHeight() int32 {
 	if m != nil {
-		return m.Delta
+		return m.Height
 	}
 	return 0
 }
 
-type SearchResponse struct {
+type AddTargetRequest struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	TotalMatches         int32    `protobuf:"varint,2,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Matrix               *Matrix  `protobuf:"bytes,3,opt,name=matrix,proto3" json:"matrix,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
-func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
-func (*SearchResponse) ProtoMessage()    {}
-func (*SearchResponse) Descriptor() ([]byte, []int) {
+func (m *AddTargetRequest) Reset()         { *m = AddTargetRequest{} }
+func (m *AddTargetRequest) String() string { return proto.CompactTextString(m) }
+func (*AddTargetRequest) ProtoMessage()    {}
+func (*AddTargetRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{7}
 }
 
-func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+func (m *AddTargetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddTargetRequest.Unmarshal(m, b)
 }
-func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+func (m *AddTargetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddTargetRequest.Marshal(b, m, deterministic)
 }
-func (m *SearchResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SearchResponse.Merge(m, src)
+func (m *AddTargetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddTargetRequest.Merge(m, src)
 }
-func (m *SearchResponse) XXX_Size() int {
-	return xxx_messageInfo_SearchResponse.Size(m)
+func (m *AddTargetRequest) XXX_Size() int {
+	return xxx_messageInfo_AddTargetRequest.Size(m)
 }
-func (m *SearchResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+func (m *AddTargetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddTargetRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+var xxx_messageInfo_AddTargetRequest proto.InternalMessageInfo
 
-func (m *SearchResponse) GetApi() string {
+func (m *AddTargetRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *SearchResponse) GetTotalMatches() int32 {
+func (m *AddTargetRequest) GetName() string {
 	if m != nil {
-		return m.TotalMatches
+		return m.Name
 	}
-	return 0
+	return ""
 }
 
-type GetMatchesRequest struct {
+func (m *AddTargetRequest) GetMatrix() *Matrix {
+	if m != nil {
+		return m.Matrix
+	}
+	return nil
+}
+
+type AddTargetResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetMatchesRequest) Reset()         { *m = GetMatchesRequest{} }
-func (m *GetMatchesRequest) String() string { return proto.CompactTextString(m) }
-func (*GetMatchesRequest) ProtoMessage()    {}
-func (*GetMatchesRequest) Descriptor() ([]byte, []int) {
+func (m *AddTargetResponse) Reset()         { *m = AddTargetResponse{} }
+func (m *AddTargetResponse) String() string { return proto.CompactTextString(m) }
+func (*AddTargetResponse) ProtoMessage()    {}
+func (*AddTargetResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{8}
 }
 
-func (m *GetMatchesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetMatchesRequest.Unmarshal(m, b)
+func (m *AddTargetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddTargetResponse.Unmarshal(m, b)
 }
-func (m *GetMatchesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetMatchesRequest.Marshal(b, m, deterministic)
+func (m *AddTargetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddTargetResponse.Marshal(b, m, deterministic)
 }
-func (m *GetMatchesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetMatchesRequest.Merge(m, src)
+func (m *AddTargetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddTargetResponse.Merge(m, src)
 }
-func (m *GetMatchesRequest) XXX_Size() int {
-	return xxx_messageInfo_GetMatchesRequest.Size(m)
+func (m *AddTargetResponse) XXX_Size() int {
+	return xxx_messageInfo_AddTargetResponse.Size(m)
 }
-func (m *GetMatchesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetMatchesRequest.DiscardUnknown(m)
+func (m *AddTargetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddTargetResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetMatchesRequest proto.InternalMessageInfo
+var xxx_messageInfo_AddTargetResponse proto.InternalMessageInfo
 
-func (m *GetMatchesRequest) GetApi() string {
+func (m *AddTargetResponse) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-type GetMatchesResponse struct {
+type RemoveTargetRequest struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Matches              []*Match `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetMatchesResponse) Reset()         { *m = GetMatchesResponse{} }
-func (m *GetMatchesResponse) String() string { return proto.CompactTextString(m) }
-func (*GetMatchesResponse) ProtoMessage()    {}
-func (*GetMatchesResponse) Descriptor() ([]byte, []int) {
+func (m *RemoveTargetRequest) Reset()         { *m = RemoveTargetRequest{} }
+func (m *RemoveTargetRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveTargetRequest) ProtoMessage()    {}
+func (*RemoveTargetRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{9}
 }
 
-func (m *GetMatchesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetMatchesResponse.Unmarshal(m, b)
+func (m *RemoveTargetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveTargetRequest.Unmarshal(m, b)
 }
-func (m *GetMatchesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetMatchesResponse.Marshal(b, m, deterministic)
+func (m *RemoveTargetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveTargetRequest.Marshal(b, m, deterministic)
 }
-func (m *GetMatchesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetMatchesResponse.Merge(m, src)
+func (m *RemoveTargetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveTargetRequest.Merge(m, src)
 }
-func (m *GetMatchesResponse) XXX_Size() int {
-	return xxx_messageInfo_GetMatchesResponse.Size(m)
+func (m *RemoveTargetRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveTargetRequest.Size(m)
 }
-func (m *GetMatchesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetMatchesResponse.DiscardUnknown(m)
+func (m *RemoveTargetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveTargetRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetMatchesResponse proto.InternalMessageInfo
+var xxx_messageInfo_RemoveTargetRequest proto.InternalMessageInfo
 
-func (m *GetMatchesResponse) GetApi() string {
+func (m *RemoveTargetRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *GetMatchesResponse) GetMatches() []*Match {
+func (m *RemoveTargetRequest) GetName() string {
 	if m != nil {
-		return m.Matches
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-type GetMatchRequest struct {
+type RemoveTargetResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Id                   int32    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetMatchRequest) Reset()         { *m = GetMatchRequest{} }
-func (m *GetMatchRequest) String() string { return proto.CompactTextString(m) }
-func (*GetMatchRequest) ProtoMessage()    {}
-func (*GetMatchRequest) Descriptor() ([]byte, []int) {
+func (m *RemoveTargetResponse) Reset()         { *m = RemoveTargetResponse{} }
+func (m *RemoveTargetResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveTargetResponse) ProtoMessage()    {}
+func (*RemoveTargetResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{10}
 }
 
-func (m *GetMatchRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetMatchRequest.Unmarshal(m, b)
+func (m *RemoveTargetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveTargetResponse.Unmarshal(m, b)
 }
-func (m *GetMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetMatchRequest.Marshal(b, m, deterministic)
+func (m *RemoveTargetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveTargetResponse.Marshal(b, m, deterministic)
 }
-func (m *GetMatchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetMatchRequest.Merge(m, src)
+func (m *RemoveTargetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveTargetResponse.Merge(m, src)
 }
-func (m *GetMatchRequest) XXX_Size() int {
-	return xxx_messageInfo_GetMatchRequest.Size(m)
+func (m *RemoveTargetResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveTargetResponse.Size(m)
 }
-func (m *GetMatchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetMatchRequest.DiscardUnknown(m)
+func (m *RemoveTargetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveTargetResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetMatchRequest proto.InternalMessageInfo
+var xxx_messageInfo_RemoveTargetResponse proto.InternalMessageInfo
 
-func (m *GetMatchRequest) GetApi() string {
+func (m *RemoveTargetResponse) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *GetMatchRequest) GetId() int32 {
-	if m != nil {
-		return m.Id
-	}
-	return 0
-}
-
-type GetMatchResponse struct {
+type ListTargetsRequest struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
-	Match                *Match   `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`
-	Matrix               *Matrix  `protobuf:"bytes,3,opt,name=matrix,proto3" json:"matrix,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetMatchResponse) Reset()         { *m = GetMatchResponse{} }
-func (m *GetMatchResponse) String() string { return proto.CompactTextString(m) }
-func (*GetMatchResponse) ProtoMessage()    {}
-func (*GetMatchResponse) Descriptor() ([]byte, []int) {
+func (m *ListTargetsRequest) Reset()         { *m = ListTargetsRequest{} }
+func (m *ListTargetsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTargetsRequest) ProtoMessage()    {}
+func (*ListTargetsRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a0b84a42fa06f626, []int{11}
 }
 
-func (m *GetMatchResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetMatchResponse.Unmarshal(m, b)
+func (m *ListTargetsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTargetsRequest.Unmarshal(m, b)
 }
-func (m *GetMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetMatchResponse.Marshal(b, m, deterministic)
+func (m *ListTargetsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTargetsRequest.Marshal(b, m, deterministic)
 }
-func (m *GetMatchResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetMatchResponse.Merge(m, src)
+func (m *ListTargetsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTargetsRequest.Merge(m, src)
 }
-func (m *GetMatchResponse) XXX_Size() int {
-	return xxx_messageInfo_GetMatchResponse.Size(m)
+func (m *ListTargetsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTargetsRequest.Size(m)
 }
-func (m *GetMatchResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetMatchResponse.DiscardUnknown(m)
+func (m *ListTargetsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTargetsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetMatchResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListTargetsRequest proto.InternalMessageInfo
 
-func (m *GetMatchResponse) GetApi() string {
+func (m *ListTargetsRequest) GetApi() string {
 	if m != nil {
 		return m.Api
 	}
 	return ""
 }
 
-func (m *GetMatchResponse) GetMatch() *Match {
+type ListTargetsResponse struct {
+	Api                  string    `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Targets              []*Target `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListTargetsResponse) Reset()         { *m = ListTargetsResponse{} }
+func (m *ListTargetsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTargetsResponse) ProtoMessage()    {}
+func (*ListTargetsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{12}
+}
+
+func (m *ListTargetsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTargetsResponse.Unmarshal(m, b)
+}
+func (m *ListTargetsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTargetsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTargetsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTargetsResponse.Merge(m, src)
+}
+func (m *ListTargetsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTargetsResponse.Size(m)
+}
+func (m *ListTargetsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTargetsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTargetsResponse proto.InternalMessageInfo
+
+func (m *ListTargetsResponse) GetApi() string {
 	if m != nil {
-		return m.Match
+		return m.Api
 	}
-	return nil
+	return ""
 }
 
-func (m *GetMatchResponse) GetMatrix() *Matrix {
+func (m *ListTargetsResponse) GetTargets() []*Target {
 	if m != nil {
-		return m.Matrix
+		return m.Targets
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("finder2d.v1.MatrixName", MatrixName_name, MatrixName_value)
-	proto.RegisterType((*Matrix)(nil), "finder2d.v1.Matrix")
-	proto.RegisterType((*Match)(nil), "finder2d.v1.Match")
-	proto.RegisterType((*GetMatrixRequest)(nil), "finder2d.v1.GetMatrixRequest")
-	proto.RegisterType((*GetMatrixResponse)(nil), "finder2d.v1.GetMatrixResponse")
-	proto.RegisterType((*LoadMatrixRequest)(nil), "finder2d.v1.LoadMatrixRequest")
-	proto.RegisterType((*LoadMatrixResponse)(nil), "finder2d.v1.LoadMatrixResponse")
-	proto.RegisterType((*SearchRequest)(nil), "finder2d.v1.SearchRequest")
-	proto.RegisterType((*SearchResponse)(nil), "finder2d.v1.SearchResponse")
-	proto.RegisterType((*GetMatchesRequest)(nil), "finder2d.v1.GetMatchesRequest")
-	proto.RegisterType((*GetMatchesResponse)(nil), "finder2d.v1.GetMatchesResponse")
-	proto.RegisterType((*GetMatchRequest)(nil), "finder2d.v1.GetMatchRequest")
-	proto.RegisterType((*GetMatchResponse)(nil), "finder2d.v1.GetMatchResponse")
+type SearchRequest struct {
+	Api                  string    `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Percentage           float32   `protobuf:"fixed32,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Delta                int32     `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	Strategy             string    `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Workers              int32     `protobuf:"varint,5,opt,name=workers,proto3" json:"workers,omitempty"`
+	AnyOrientation       bool      `protobuf:"varint,6,opt,name=any_orientation,json=anyOrientation,proto3" json:"any_orientation,omitempty"`
+	Scales               []float32 `protobuf:"fixed32,7,rep,packed,name=scales,proto3" json:"scales,omitempty"`
+	Metric               string    `protobuf:"bytes,8,opt,name=metric,proto3" json:"metric,omitempty"`
+	Weights              []float32 `protobuf:"fixed32,9,rep,packed,name=weights,proto3" json:"weights,omitempty"`
+	Reduction            string    `protobuf:"bytes,10,opt,name=reduction,proto3" json:"reduction,omitempty"`
+	IouThreshold         float32   `protobuf:"fixed32,11,opt,name=iou_threshold,json=iouThreshold,proto3" json:"iou_threshold,omitempty"`
+	TopK                 int32     `protobuf:"varint,12,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	Comparison           string    `protobuf:"bytes,13,opt,name=comparison,proto3" json:"comparison,omitempty"`
+	Tolerance            float32   `protobuf:"fixed32,14,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+	Significance         bool      `protobuf:"varint,15,opt,name=significance,proto3" json:"significance,omitempty"`
+	MaxPValue            float64   `protobuf:"fixed64,16,opt,name=max_p_value,json=maxPValue,proto3" json:"max_p_value,omitempty"`
+	Border               bool      `protobuf:"varint,17,opt,name=border,proto3" json:"border,omitempty"`
+	MinVisible           float32   `protobuf:"fixed32,18,opt,name=min_visible,json=minVisible,proto3" json:"min_visible,omitempty"`
+	Wrap                 bool      `protobuf:"varint,19,opt,name=wrap,proto3" json:"wrap,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func init() { proto.RegisterFile("service.proto", fileDescriptor_a0b84a42fa06f626) }
-
-var fileDescriptor_a0b84a42fa06f626 = []byte{
-	// 841 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdd, 0x6e, 0xdc, 0x44,
-	0x14, 0xc6, 0xde, 0xec, 0xa6, 0x39, 0x69, 0xd2, 0xed, 0xb4, 0x4a, 0xb6, 0x4b, 0xda, 0x5a, 0x43,
-	0x41, 0x51, 0xda, 0xd8, 0xc9, 0xb6, 0x12, 0x68, 0x2f, 0x10, 0xa1, 0x09, 0xb9, 0x61, 0xf9, 0x71,
-	0x16, 0x21, 0x21, 0x10, 0x9a, 0xb5, 0x27, 0xf6, 0x94, 0xf5, 0x8c, 0x3b, 0x33, 0xbb, 0x49, 0x14,
-	0x55, 0x48, 0x3c, 0x02, 0x5c, 0x20, 0xf1, 0x5a, 0x3c, 0x00, 0x37, 0x48, 0xbc, 0x06, 0xf2, 0x78,
-	0xdd, 0xf5, 0xfe, 0x18, 0x51, 0xc1, 0xd5, 0xee, 0x39, 0xf3, 0x9d, 0xef, 0x3b, 0xe7, 0xcc, 0x67,
-	0x1b, 0x36, 0x14, 0x95, 0x63, 0x16, 0x50, 0x37, 0x95, 0x42, 0x0b, 0xb4, 0x7e, 0xce, 0x78, 0x48,
-	0x65, 0x27, 0x74, 0xc7, 0x87, 0xed, 0x9d, 0x48, 0x88, 0x68, 0x48, 0x3d, 0x92, 0x32, 0x8f, 0x70,
-	0x2e, 0x34, 0xd1, 0x4c, 0x70, 0x95, 0x43, 0xdb, 0x4f, 0xcc, 0x4f, 0xb0, 0x1f, 0x51, 0xbe, 0xaf,
-	0x2e, 0x48, 0x14, 0x51, 0xe9, 0x89, 0xd4, 0x20, 0x16, 0xd1, 0xf8, 0x0b, 0x68, 0xf4, 0x88, 0x96,
-	0xec, 0x12, 0xdd, 0x85, 0xfa, 0x05, 0x0b, 0x75, 0xdc, 0xaa, 0x39, 0xd6, 0x6e, 0xdd, 0xcf, 0x03,
-	0xb4, 0x05, 0x8d, 0x98, 0xb2, 0x28, 0xd6, 0xad, 0x15, 0x93, 0x9e, 0x44, 0xa8, 0x05, 0xab, 0x81,
-	0xe0, 0x9a, 0x72, 0xdd, 0xaa, 0x3b, 0xd6, 0xee, 0x9a, 0x5f, 0x84, 0xf8, 0x39, 0xd4, 0x7b, 0x44,
-	0x07, 0x31, 0xba, 0x09, 0xd6, 0x65, 0xcb, 0x32, 0x55, 0xd6, 0x65, 0x16, 0x5d, 0xb5, 0xec, 0x3c,
-	0xba, 0x42, 0x0f, 0x00, 0x52, 0x2a, 0x03, 0xca, 0x35, 0x89, 0xa8, 0x51, 0xb4, 0xfd, 0x52, 0x06,
-	0x7f, 0x09, 0xcd, 0x53, 0xaa, 0xf3, 0xce, 0x7c, 0xfa, 0x72, 0x44, 0x95, 0x46, 0x4d, 0xa8, 0x91,
-	0x94, 0x19, 0xc6, 0x35, 0x3f, 0xfb, 0x8b, 0x1e, 0xc3, 0x0a, 0x27, 0x09, 0x35, 0xb4, 0x9b, 0x9d,
-	0x6d, 0xb7, 0xb4, 0x24, 0x37, 0xaf, 0xfd, 0x8c, 0x24, 0xd4, 0x37, 0x20, 0xfc, 0x23, 0xdc, 0x2e,
-	0x51, 0xaa, 0x54, 0x70, 0x45, 0xff, 0x23, 0x27, 0x7a, 0x0c, 0x8d, 0xc4, 0xe4, 0xcc, 0x08, 0xeb,
-	0x9d, 0x3b, 0x4b, 0xe0, 0xfe, 0x04, 0x92, 0x35, 0xf0, 0xa9, 0x20, 0xe1, 0xff, 0x39, 0xd4, 0x9b,
-	0x35, 0xf0, 0x1e, 0xa0, 0x72, 0x03, 0x55, 0x2b, 0xc0, 0x5f, 0xc3, 0xc6, 0x19, 0x25, 0x32, 0x88,
-	0xab, 0x9b, 0x9c, 0xbd, 0x3f, 0x7b, 0xfe, 0xfe, 0x32, 0x33, 0x85, 0x74, 0xa8, 0x49, 0x61, 0x26,
-	0x13, 0xe0, 0x53, 0xd8, 0x2c, 0x88, 0x2b, 0xf7, 0xff, 0x0e, 0x6c, 0x68, 0xa1, 0xc9, 0xf0, 0xfb,
-	0x24, 0x33, 0x11, 0x55, 0x13, 0xcf, 0xdc, 0x34, 0xc9, 0x5e, 0x9e, 0xc3, 0xef, 0x16, 0x77, 0x99,
-	0x45, 0x95, 0x5d, 0xe2, 0x3e, 0xa0, 0x32, 0xac, 0x52, 0xf3, 0x09, 0xac, 0x4e, 0xd5, 0x6a, 0xbb,
-	0xeb, 0x1d, 0x34, 0xbf, 0xc6, 0x20, 0xf6, 0x0b, 0x08, 0x7e, 0x0a, 0xb7, 0x0a, 0xd6, 0xea, 0x05,
-	0x6d, 0x82, 0xcd, 0xc2, 0x49, 0xef, 0x36, 0x0b, 0xf1, 0x75, 0x61, 0xe8, 0x7f, 0x1c, 0x7e, 0x17,
-	0xea, 0x46, 0xc5, 0x14, 0x2e, 0x6f, 0x23, 0x07, 0xbc, 0xd1, 0xc5, 0xef, 0x3d, 0x02, 0x98, 0x3a,
-	0x07, 0x01, 0x34, 0xce, 0x3e, 0xff, 0xca, 0x7f, 0x7e, 0xd2, 0x7c, 0x2b, 0xfb, 0xdf, 0x3f, 0xf2,
-	0x4f, 0x4f, 0xfa, 0x4d, 0xab, 0xf3, 0xeb, 0x0a, 0xdc, 0xf8, 0x24, 0x27, 0x39, 0x46, 0x3f, 0xc0,
-	0xda, 0xeb, 0xa7, 0x05, 0xdd, 0x9f, 0x21, 0x9f, 0x7f, 0x30, 0xdb, 0x0f, 0xaa, 0x8e, 0xf3, 0x39,
-	0xf1, 0xc3, 0x9f, 0x7e, 0xff, 0xf3, 0x17, 0xfb, 0x1e, 0xda, 0x36, 0x6f, 0xac, 0xf1, 0xa1, 0x97,
-	0xb7, 0x45, 0x95, 0x77, 0x9d, 0x99, 0xf8, 0x15, 0x7a, 0x09, 0x30, 0x35, 0x26, 0x9a, 0xa5, 0x5b,
-	0x78, 0x64, 0xda, 0x0f, 0x2b, 0xcf, 0x27, 0x7a, 0xd8, 0xe8, 0xed, 0xe0, 0x2a, 0xbd, 0xae, 0xb5,
-	0x87, 0xbe, 0x85, 0x46, 0x6e, 0x45, 0xd4, 0x9e, 0xa1, 0x9b, 0x31, 0x7e, 0xfb, 0xed, 0xa5, 0x67,
-	0x13, 0x99, 0x7b, 0x46, 0xe6, 0x0e, 0xde, 0x2c, 0x64, 0x94, 0x39, 0xcf, 0xd8, 0xcf, 0x01, 0xa6,
-	0xc6, 0x43, 0xcb, 0xf6, 0x53, 0x32, 0xee, 0xdc, 0x40, 0x8b, 0x8e, 0xc5, 0xdb, 0x46, 0xe9, 0x36,
-	0xba, 0x55, 0x1a, 0xc8, 0x30, 0x53, 0xb8, 0x51, 0xc0, 0xd1, 0xce, 0x52, 0x96, 0x42, 0xe3, 0x7e,
-	0xc5, 0xe9, 0x44, 0x61, 0xc7, 0x28, 0x6c, 0xa1, 0xbb, 0x73, 0x0a, 0xde, 0x35, 0x0b, 0x5f, 0x7d,
-	0xfc, 0x97, 0xfd, 0xf3, 0xd1, 0x1f, 0x36, 0xfa, 0x0e, 0x9a, 0x85, 0x3f, 0x9c, 0xb3, 0xfc, 0xf3,
-	0x84, 0x8f, 0x4b, 0x9e, 0x79, 0x14, 0x6b, 0x9d, 0xaa, 0xae, 0xe7, 0x45, 0x4c, 0xc7, 0xa3, 0x81,
-	0x1b, 0x88, 0xc4, 0x7b, 0x21, 0x62, 0xc2, 0x43, 0x79, 0xe5, 0x15, 0xf2, 0x6d, 0x54, 0xa4, 0x3e,
-	0x8a, 0x12, 0xc2, 0x86, 0x19, 0xaa, 0x53, 0x3b, 0x74, 0x0f, 0xf6, 0x2c, 0xab, 0xd3, 0x24, 0x69,
-	0x3a, 0x64, 0x81, 0xf9, 0x42, 0x79, 0x2f, 0x94, 0xe0, 0xdd, 0x85, 0x8c, 0xff, 0x21, 0xd4, 0x9e,
-	0x1d, 0x3c, 0x43, 0xef, 0xc3, 0xbe, 0x4f, 0xf5, 0x48, 0x72, 0x1a, 0x3a, 0x17, 0x31, 0xe5, 0x8e,
-	0x8e, 0xa9, 0xa3, 0x89, 0x8c, 0xa8, 0x76, 0xf2, 0xfb, 0x76, 0x98, 0x72, 0xb8, 0xd0, 0xce, 0xb9,
-	0x18, 0xf1, 0xd0, 0x45, 0x0d, 0x58, 0xf9, 0xcd, 0xb6, 0x56, 0xfd, 0xa3, 0xac, 0xfe, 0x00, 0x75,
-	0xe1, 0x83, 0xd9, 0x7a, 0xe2, 0xc8, 0x7c, 0x57, 0x59, 0x1d, 0xe3, 0x63, 0x32, 0x64, 0xa1, 0x23,
-	0xa4, 0x93, 0x30, 0xa5, 0x18, 0x8f, 0x9c, 0x94, 0x48, 0x92, 0x50, 0x4d, 0xa5, 0x92, 0x7d, 0xd8,
-	0x7a, 0xbd, 0x88, 0x63, 0x11, 0x8c, 0x92, 0xec, 0xad, 0x97, 0x75, 0x88, 0xba, 0xff, 0x66, 0x05,
-	0xde, 0x60, 0x28, 0x06, 0x5e, 0x42, 0x94, 0xa6, 0xd2, 0xf3, 0x4f, 0x8e, 0x8e, 0x7b, 0x27, 0x6e,
-	0x12, 0x7e, 0x63, 0x8f, 0x0f, 0x07, 0x0d, 0xf3, 0x55, 0x7e, 0xfa, 0x77, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x6b, 0x0a, 0x40, 0xda, 0xff, 0x07, 0x00, 0x00,
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{13}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetPercentage() float32 {
+	if m != nil {
+		return m.Percentage
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetDelta() int32 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetWorkers() int32 {
+	if m != nil {
+		return m.Workers
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetAnyOrientation() bool {
+	if m != nil {
+		return m.AnyOrientation
+	}
+	return false
+}
+
+func (m *SearchRequest) GetScales() []float32 {
+	if m != nil {
+		return m.Scales
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetMetric() string {
+	if m != nil {
+		return m.Metric
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetWeights() []float32 {
+	if m != nil {
+		return m.Weights
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetReduction() string {
+	if m != nil {
+		return m.Reduction
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetIouThreshold() float32 {
+	if m != nil {
+		return m.IouThreshold
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetTopK() int32 {
+	if m != nil {
+		return m.TopK
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetComparison() string {
+	if m != nil {
+		return m.Comparison
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetTolerance() float32 {
+	if m != nil {
+		return m.Tolerance
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetSignificance() bool {
+	if m != nil {
+		return m.Significance
+	}
+	return false
+}
+
+func (m *SearchRequest) GetMaxPValue() float64 {
+	if m != nil {
+		return m.MaxPValue
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetBorder() bool {
+	if m != nil {
+		return m.Border
+	}
+	return false
+}
+
+func (m *SearchRequest) GetMinVisible() float32 {
+	if m != nil {
+		return m.MinVisible
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetWrap() bool {
+	if m != nil {
+		return m.Wrap
+	}
+	return false
+}
+
+type SearchResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	TotalMatches         int32    `protobuf:"varint,2,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{14}
+}
+
+func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+}
+func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse.Merge(m, src)
+}
+func (m *SearchResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse.Size(m)
+}
+func (m *SearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+
+func (m *SearchResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SearchResponse) GetTotalMatches() int32 {
+	if m != nil {
+		return m.TotalMatches
+	}
+	return 0
+}
+
+type SearchProgress struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ScannedRows          int32    `protobuf:"varint,2,opt,name=scanned_rows,json=scannedRows,proto3" json:"scanned_rows,omitempty"`
+	TotalRows            int32    `protobuf:"varint,3,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	Done                 bool     `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	TotalMatches         int32    `protobuf:"varint,5,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchProgress) Reset()         { *m = SearchProgress{} }
+func (m *SearchProgress) String() string { return proto.CompactTextString(m) }
+func (*SearchProgress) ProtoMessage()    {}
+func (*SearchProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{15}
+}
+
+func (m *SearchProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchProgress.Unmarshal(m, b)
+}
+func (m *SearchProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchProgress.Marshal(b, m, deterministic)
+}
+func (m *SearchProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchProgress.Merge(m, src)
+}
+func (m *SearchProgress) XXX_Size() int {
+	return xxx_messageInfo_SearchProgress.Size(m)
+}
+func (m *SearchProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchProgress proto.InternalMessageInfo
+
+func (m *SearchProgress) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SearchProgress) GetScannedRows() int32 {
+	if m != nil {
+		return m.ScannedRows
+	}
+	return 0
+}
+
+func (m *SearchProgress) GetTotalRows() int32 {
+	if m != nil {
+		return m.TotalRows
+	}
+	return 0
+}
+
+func (m *SearchProgress) GetDone() bool {
+	if m != nil {
+		return m.Done
+	}
+	return false
+}
+
+func (m *SearchProgress) GetTotalMatches() int32 {
+	if m != nil {
+		return m.TotalMatches
+	}
+	return 0
+}
+
+type GetMatchesRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMatchesRequest) Reset()         { *m = GetMatchesRequest{} }
+func (m *GetMatchesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMatchesRequest) ProtoMessage()    {}
+func (*GetMatchesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{16}
+}
+
+func (m *GetMatchesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMatchesRequest.Unmarshal(m, b)
+}
+func (m *GetMatchesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMatchesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMatchesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMatchesRequest.Merge(m, src)
+}
+func (m *GetMatchesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMatchesRequest.Size(m)
+}
+func (m *GetMatchesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMatchesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMatchesRequest proto.InternalMessageInfo
+
+func (m *GetMatchesRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+type GetMatchesResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Matches              []*Match `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMatchesResponse) Reset()         { *m = GetMatchesResponse{} }
+func (m *GetMatchesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMatchesResponse) ProtoMessage()    {}
+func (*GetMatchesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{17}
+}
+
+func (m *GetMatchesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMatchesResponse.Unmarshal(m, b)
+}
+func (m *GetMatchesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMatchesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMatchesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMatchesResponse.Merge(m, src)
+}
+func (m *GetMatchesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMatchesResponse.Size(m)
+}
+func (m *GetMatchesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMatchesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMatchesResponse proto.InternalMessageInfo
+
+func (m *GetMatchesResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetMatchesResponse) GetMatches() []*Match {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+type GetMatchRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   int32    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMatchRequest) Reset()         { *m = GetMatchRequest{} }
+func (m *GetMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMatchRequest) ProtoMessage()    {}
+func (*GetMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{18}
+}
+
+func (m *GetMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMatchRequest.Unmarshal(m, b)
+}
+func (m *GetMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMatchRequest.Merge(m, src)
+}
+func (m *GetMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMatchRequest.Size(m)
+}
+func (m *GetMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMatchRequest proto.InternalMessageInfo
+
+func (m *GetMatchRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetMatchRequest) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetMatchResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Match                *Match   `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`
+	Matrix               *Matrix  `protobuf:"bytes,3,opt,name=matrix,proto3" json:"matrix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMatchResponse) Reset()         { *m = GetMatchResponse{} }
+func (m *GetMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMatchResponse) ProtoMessage()    {}
+func (*GetMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{19}
+}
+
+func (m *GetMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMatchResponse.Unmarshal(m, b)
+}
+func (m *GetMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMatchResponse.Merge(m, src)
+}
+func (m *GetMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMatchResponse.Size(m)
+}
+func (m *GetMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMatchResponse proto.InternalMessageInfo
+
+func (m *GetMatchResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetMatchResponse) GetMatch() *Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+func (m *GetMatchResponse) GetMatrix() *Matrix {
+	if m != nil {
+		return m.Matrix
+	}
+	return nil
+}
+
+type GetOverlayRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Format               string   `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOverlayRequest) Reset()         { *m = GetOverlayRequest{} }
+func (m *GetOverlayRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOverlayRequest) ProtoMessage()    {}
+func (*GetOverlayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{20}
+}
+
+func (m *GetOverlayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOverlayRequest.Unmarshal(m, b)
+}
+func (m *GetOverlayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOverlayRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOverlayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOverlayRequest.Merge(m, src)
+}
+func (m *GetOverlayRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOverlayRequest.Size(m)
+}
+func (m *GetOverlayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOverlayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOverlayRequest proto.InternalMessageInfo
+
+func (m *GetOverlayRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetOverlayRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+type GetHeatmapRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHeatmapRequest) Reset()         { *m = GetHeatmapRequest{} }
+func (m *GetHeatmapRequest) String() string { return proto.CompactTextString(m) }
+func (*GetHeatmapRequest) ProtoMessage()    {}
+func (*GetHeatmapRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{21}
+}
+
+func (m *GetHeatmapRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHeatmapRequest.Unmarshal(m, b)
+}
+func (m *GetHeatmapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHeatmapRequest.Marshal(b, m, deterministic)
+}
+func (m *GetHeatmapRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHeatmapRequest.Merge(m, src)
+}
+func (m *GetHeatmapRequest) XXX_Size() int {
+	return xxx_messageInfo_GetHeatmapRequest.Size(m)
+}
+func (m *GetHeatmapRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHeatmapRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHeatmapRequest proto.InternalMessageInfo
+
+func (m *GetHeatmapRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+type GetHeatmapResponse struct {
+	Api                  string    `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Width                int32     `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Scores               []float32 `protobuf:"fixed32,4,rep,packed,name=scores,proto3" json:"scores,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GetHeatmapResponse) Reset()         { *m = GetHeatmapResponse{} }
+func (m *GetHeatmapResponse) String() string { return proto.CompactTextString(m) }
+func (*GetHeatmapResponse) ProtoMessage()    {}
+func (*GetHeatmapResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{22}
+}
+
+func (m *GetHeatmapResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHeatmapResponse.Unmarshal(m, b)
+}
+func (m *GetHeatmapResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHeatmapResponse.Marshal(b, m, deterministic)
+}
+func (m *GetHeatmapResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHeatmapResponse.Merge(m, src)
+}
+func (m *GetHeatmapResponse) XXX_Size() int {
+	return xxx_messageInfo_GetHeatmapResponse.Size(m)
+}
+func (m *GetHeatmapResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHeatmapResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHeatmapResponse proto.InternalMessageInfo
+
+func (m *GetHeatmapResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetHeatmapResponse) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *GetHeatmapResponse) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetHeatmapResponse) GetScores() []float32 {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+type SuggestRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SuggestRequest) Reset()         { *m = SuggestRequest{} }
+func (m *SuggestRequest) String() string { return proto.CompactTextString(m) }
+func (*SuggestRequest) ProtoMessage()    {}
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{23}
+}
+
+func (m *SuggestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SuggestRequest.Unmarshal(m, b)
+}
+func (m *SuggestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SuggestRequest.Marshal(b, m, deterministic)
+}
+func (m *SuggestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuggestRequest.Merge(m, src)
+}
+func (m *SuggestRequest) XXX_Size() int {
+	return xxx_messageInfo_SuggestRequest.Size(m)
+}
+func (m *SuggestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuggestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SuggestRequest proto.InternalMessageInfo
+
+func (m *SuggestRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+type SuggestResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Percentage           float32  `protobuf:"fixed32,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Delta                int32    `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	Images               int32    `protobuf:"varint,4,opt,name=images,proto3" json:"images,omitempty"`
+	HistogramLow         float32  `protobuf:"fixed32,5,opt,name=histogram_low,json=histogramLow,proto3" json:"histogram_low,omitempty"`
+	Histogram            []int32  `protobuf:"varint,6,rep,packed,name=histogram,proto3" json:"histogram,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SuggestResponse) Reset()         { *m = SuggestResponse{} }
+func (m *SuggestResponse) String() string { return proto.CompactTextString(m) }
+func (*SuggestResponse) ProtoMessage()    {}
+func (*SuggestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a0b84a42fa06f626, []int{24}
+}
+
+func (m *SuggestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SuggestResponse.Unmarshal(m, b)
+}
+func (m *SuggestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SuggestResponse.Marshal(b, m, deterministic)
+}
+func (m *SuggestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuggestResponse.Merge(m, src)
+}
+func (m *SuggestResponse) XXX_Size() int {
+	return xxx_messageInfo_SuggestResponse.Size(m)
+}
+func (m *SuggestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuggestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SuggestResponse proto.InternalMessageInfo
+
+func (m *SuggestResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *SuggestResponse) GetPercentage() float32 {
+	if m != nil {
+		return m.Percentage
+	}
+	return 0
+}
+
+func (m *SuggestResponse) GetDelta() int32 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *SuggestResponse) GetImages() int32 {
+	if m != nil {
+		return m.Images
+	}
+	return 0
+}
+
+func (m *SuggestResponse) GetHistogramLow() float32 {
+	if m != nil {
+		return m.HistogramLow
+	}
+	return 0
+}
+
+func (m *SuggestResponse) GetHistogram() []int32 {
+	if m != nil {
+		return m.Histogram
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("finder2d.v1.MatrixName", MatrixName_name, MatrixName_value)
+	proto.RegisterType((*Matrix)(nil), "finder2d.v1.Matrix")
+	proto.RegisterType((*Match)(nil), "finder2d.v1.Match")
+	proto.RegisterType((*GetMatrixRequest)(nil), "finder2d.v1.GetMatrixRequest")
+	proto.RegisterType((*GetMatrixResponse)(nil), "finder2d.v1.GetMatrixResponse")
+	proto.RegisterType((*LoadMatrixRequest)(nil), "finder2d.v1.LoadMatrixRequest")
+	proto.RegisterType((*LoadMatrixResponse)(nil), "finder2d.v1.LoadMatrixResponse")
+	proto.RegisterType((*Target)(nil), "finder2d.v1.Target")
+	proto.RegisterType((*AddTargetRequest)(nil), "finder2d.v1.AddTargetRequest")
+	proto.RegisterType((*AddTargetResponse)(nil), "finder2d.v1.AddTargetResponse")
+	proto.RegisterType((*RemoveTargetRequest)(nil), "finder2d.v1.RemoveTargetRequest")
+	proto.RegisterType((*RemoveTargetResponse)(nil), "finder2d.v1.RemoveTargetResponse")
+	proto.RegisterType((*ListTargetsRequest)(nil), "finder2d.v1.ListTargetsRequest")
+	proto.RegisterType((*ListTargetsResponse)(nil), "finder2d.v1.ListTargetsResponse")
+	proto.RegisterType((*SearchRequest)(nil), "finder2d.v1.SearchRequest")
+	proto.RegisterType((*SearchResponse)(nil), "finder2d.v1.SearchResponse")
+	proto.RegisterType((*SearchProgress)(nil), "finder2d.v1.SearchProgress")
+	proto.RegisterType((*GetMatchesRequest)(nil), "finder2d.v1.GetMatchesRequest")
+	proto.RegisterType((*GetMatchesResponse)(nil), "finder2d.v1.GetMatchesResponse")
+	proto.RegisterType((*GetMatchRequest)(nil), "finder2d.v1.GetMatchRequest")
+	proto.RegisterType((*GetMatchResponse)(nil), "finder2d.v1.GetMatchResponse")
+	proto.RegisterType((*GetOverlayRequest)(nil), "finder2d.v1.GetOverlayRequest")
+	proto.RegisterType((*GetHeatmapRequest)(nil), "finder2d.v1.GetHeatmapRequest")
+	proto.RegisterType((*GetHeatmapResponse)(nil), "finder2d.v1.GetHeatmapResponse")
+	proto.RegisterType((*SuggestRequest)(nil), "finder2d.v1.SuggestRequest")
+	proto.RegisterType((*SuggestResponse)(nil), "finder2d.v1.SuggestResponse")
+}
+
+func init() { proto.RegisterFile("service.proto", fileDescriptor_a0b84a42fa06f626) }
+
+var fileDescriptor_a0b84a42fa06f626 = []byte{
+	// 1638 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdd, 0x6e, 0xe4, 0x48,
+	0x15, 0xc6, 0x9d, 0x74, 0x77, 0xfa, 0x74, 0x27, 0xe9, 0x54, 0x42, 0xc6, 0xe3, 0xcd, 0x24, 0x1e,
+	0xef, 0x2e, 0x44, 0xd9, 0x9d, 0xee, 0x99, 0xec, 0x4a, 0xa0, 0x20, 0x10, 0x59, 0x66, 0x98, 0x15,
+	0xec, 0x30, 0x83, 0x13, 0xe6, 0x02, 0x58, 0xb5, 0xaa, 0xed, 0x8a, 0x5d, 0x3b, 0xb6, 0xcb, 0x5b,
+	0x55, 0xdd, 0x9d, 0xd6, 0x68, 0x84, 0xc4, 0x23, 0xc0, 0x1d, 0x12, 0xd7, 0xbc, 0x00, 0xaf, 0xc1,
+	0x15, 0x0f, 0xc0, 0x0d, 0x12, 0x2f, 0x80, 0xc4, 0x2d, 0x72, 0x95, 0xdd, 0xed, 0xfe, 0x31, 0x62,
+	0x06, 0xae, 0xe2, 0x73, 0xea, 0xd4, 0xf9, 0x4e, 0x7d, 0x3e, 0xf5, 0xf9, 0xa4, 0x61, 0x5b, 0x10,
+	0x3e, 0xa6, 0x1e, 0xe9, 0xa5, 0x9c, 0x49, 0x86, 0xda, 0x37, 0x34, 0xf1, 0x09, 0x3f, 0xf7, 0x7b,
+	0xe3, 0x47, 0xd6, 0x51, 0xc0, 0x58, 0x10, 0x91, 0x3e, 0x4e, 0x69, 0x1f, 0x27, 0x09, 0x93, 0x58,
+	0x52, 0x96, 0x08, 0x1d, 0x6a, 0xdd, 0x2d, 0xad, 0x86, 0x52, 0xa6, 0x43, 0xe6, 0x4f, 0xf3, 0xa5,
+	0x8f, 0xd5, 0x1f, 0xef, 0x41, 0x40, 0x92, 0x07, 0x62, 0x82, 0x83, 0x80, 0xf0, 0x3e, 0x4b, 0xd5,
+	0xe6, 0xd5, 0x44, 0xce, 0x0b, 0x68, 0x3c, 0xc3, 0x92, 0xd3, 0x5b, 0x74, 0x00, 0xf5, 0x09, 0xf5,
+	0x65, 0x68, 0x6e, 0xd8, 0xc6, 0x69, 0xdd, 0xd5, 0x06, 0x3a, 0x84, 0x46, 0x48, 0x68, 0x10, 0x4a,
+	0x73, 0x53, 0xb9, 0x73, 0x0b, 0x99, 0xd0, 0xf4, 0x58, 0x22, 0x49, 0x22, 0xcd, 0xba, 0x6d, 0x9c,
+	0xb6, 0xdc, 0xc2, 0x74, 0xfe, 0x69, 0x40, 0xfd, 0x19, 0x96, 0x5e, 0x88, 0x3a, 0x60, 0xdc, 0x9a,
+	0x86, 0xda, 0x66, 0xdc, 0x66, 0xd6, 0xd4, 0xac, 0x69, 0x6b, 0x8a, 0x8e, 0x01, 0x52, 0xc2, 0x3d,
+	0x92, 0x48, 0x1c, 0x10, 0x05, 0x59, 0x73, 0x4b, 0x1e, 0x64, 0x43, 0x9b, 0x71, 0x4a, 0x12, 0x5d,
+	0xad, 0x02, 0x6f, 0xb9, 0x65, 0xd7, 0xbc, 0xde, 0xfa, 0xfa, 0x7a, 0x1b, 0x0b, 0xf5, 0x1e, 0x40,
+	0x5d, 0x78, 0x38, 0x22, 0x66, 0x53, 0x41, 0x69, 0x23, 0x8b, 0x96, 0x98, 0x07, 0x44, 0x9a, 0x5b,
+	0x0a, 0x20, 0xb7, 0xd0, 0x1d, 0x68, 0xa6, 0x83, 0x31, 0x8e, 0x46, 0xc4, 0x6c, 0xd9, 0xc6, 0xa9,
+	0xe1, 0x36, 0xd2, 0x97, 0x99, 0x95, 0x1d, 0x7b, 0x4c, 0x05, 0x1d, 0x46, 0xc4, 0x04, 0x95, 0xa8,
+	0x30, 0x9d, 0x9f, 0x43, 0xf7, 0x29, 0x91, 0x9a, 0x4b, 0x97, 0x7c, 0x3d, 0x22, 0x42, 0xa2, 0x2e,
+	0x6c, 0xe0, 0x94, 0x2a, 0x0a, 0x5a, 0x6e, 0xf6, 0x88, 0x3e, 0x82, 0xcd, 0x04, 0xc7, 0x44, 0xf1,
+	0xb0, 0x73, 0x7e, 0xa7, 0x57, 0x7a, 0xe3, 0x3d, 0xbd, 0xf7, 0x67, 0x38, 0x26, 0xae, 0x0a, 0x72,
+	0x7e, 0x03, 0x7b, 0xa5, 0x94, 0x22, 0x65, 0x89, 0x20, 0xff, 0x63, 0x4e, 0xf4, 0x11, 0x34, 0x62,
+	0xe5, 0x53, 0x9c, 0xb7, 0xcf, 0xf7, 0xd7, 0x84, 0xbb, 0x79, 0x88, 0xf3, 0x17, 0x03, 0xf6, 0xbe,
+	0x60, 0xd8, 0xff, 0x7f, 0x9e, 0xea, 0xad, 0x2a, 0x40, 0x16, 0x6c, 0xe1, 0x28, 0x0d, 0xf1, 0x90,
+	0xc8, 0xbc, 0x07, 0x66, 0x36, 0x42, 0xb0, 0xe9, 0x63, 0x89, 0xd5, 0xfb, 0xef, 0xb8, 0xea, 0x19,
+	0x1d, 0x41, 0x4b, 0x86, 0x9c, 0x88, 0x90, 0x45, 0xbe, 0xea, 0x80, 0x96, 0x3b, 0x77, 0x38, 0xdf,
+	0x02, 0x54, 0x3e, 0x4e, 0x15, 0xa3, 0xce, 0x4f, 0xa0, 0x71, 0xad, 0x1b, 0x01, 0xe5, 0x27, 0xd3,
+	0x8b, 0xfa, 0x00, 0xb3, 0xc6, 0xab, 0xad, 0x6f, 0xbc, 0x8d, 0x72, 0xe3, 0x39, 0x04, 0xba, 0x97,
+	0xbe, 0xaf, 0xd3, 0x55, 0x33, 0x88, 0x4a, 0x0c, 0xb6, 0xde, 0xe5, 0x55, 0x7d, 0x08, 0x7b, 0x25,
+	0x98, 0xca, 0x93, 0x7d, 0x0f, 0xf6, 0x5d, 0x12, 0xb3, 0x31, 0x79, 0x87, 0x82, 0x9c, 0x53, 0x38,
+	0x58, 0xdc, 0x5c, 0x09, 0x93, 0x11, 0x4d, 0x85, 0xd4, 0x71, 0xa2, 0x12, 0xc5, 0x79, 0x09, 0xfb,
+	0x0b, 0x71, 0x95, 0x3d, 0xfe, 0x00, 0x9a, 0xfa, 0x6a, 0x0a, 0xb3, 0x66, 0x6f, 0xac, 0x90, 0x91,
+	0x17, 0x54, 0xc4, 0x38, 0x7f, 0xda, 0x84, 0xed, 0x2b, 0x82, 0xb9, 0x17, 0x56, 0x9f, 0x70, 0x51,
+	0x81, 0x6a, 0x2b, 0x0a, 0x74, 0x00, 0x75, 0x9f, 0x44, 0x12, 0x17, 0x7a, 0xa8, 0x8c, 0xac, 0x21,
+	0x85, 0xe4, 0x58, 0x92, 0x60, 0x5a, 0x34, 0x64, 0x61, 0x67, 0xe2, 0x30, 0x61, 0xfc, 0x15, 0xe1,
+	0x22, 0xd7, 0xa4, 0xc2, 0x44, 0xdf, 0x86, 0x5d, 0x9c, 0x4c, 0x07, 0x65, 0x45, 0xcb, 0x9a, 0x73,
+	0xcb, 0xdd, 0xc1, 0xc9, 0xf4, 0xf9, 0xdc, 0x9b, 0x75, 0x91, 0x52, 0x26, 0x61, 0x36, 0xed, 0x8d,
+	0xd3, 0x9a, 0x9b, 0x5b, 0x99, 0x3f, 0x26, 0x92, 0x53, 0xaf, 0x10, 0x2a, 0x6d, 0x29, 0x48, 0xd5,
+	0x67, 0xc2, 0x6c, 0xa9, 0x0d, 0x85, 0x99, 0xdd, 0x04, 0x4e, 0xfc, 0x91, 0xa7, 0xc0, 0x40, 0xdf,
+	0x84, 0x99, 0x03, 0xbd, 0x0f, 0xdb, 0x94, 0x8d, 0x06, 0xf3, 0xbb, 0xd2, 0x56, 0xe7, 0xef, 0x50,
+	0x36, 0xba, 0x2e, 0x7c, 0x68, 0x1f, 0xea, 0x92, 0xa5, 0x83, 0x57, 0x66, 0x47, 0x9d, 0x66, 0x53,
+	0xb2, 0xf4, 0xa7, 0x19, 0x6d, 0x1e, 0x8b, 0x53, 0xcc, 0xa9, 0x60, 0x89, 0xb9, 0xad, 0x12, 0x97,
+	0x3c, 0xea, 0x06, 0xb2, 0x88, 0x70, 0x9c, 0x78, 0xc4, 0xdc, 0x51, 0x59, 0xe7, 0x0e, 0xe4, 0x40,
+	0x47, 0xd0, 0x20, 0xa1, 0x37, 0xd4, 0x53, 0x01, 0xbb, 0x8a, 0x85, 0x05, 0x1f, 0x3a, 0x86, 0x76,
+	0x8c, 0x6f, 0x07, 0x85, 0x00, 0x77, 0x95, 0x00, 0xb7, 0x62, 0x7c, 0xfb, 0x42, 0x6b, 0xf0, 0x21,
+	0x34, 0x86, 0x8c, 0xfb, 0x84, 0x9b, 0x7b, 0x6a, 0x77, 0x6e, 0xa1, 0x13, 0x68, 0xc7, 0x34, 0x19,
+	0x14, 0xfa, 0x8c, 0xf4, 0x1b, 0x8d, 0x69, 0xf2, 0x52, 0x7b, 0xb2, 0x9e, 0x9e, 0x70, 0x9c, 0x9a,
+	0xfb, 0x6a, 0x9b, 0x7a, 0x76, 0x9e, 0xc2, 0x4e, 0xd1, 0x28, 0x95, 0xcd, 0xf7, 0x3e, 0x6c, 0x4b,
+	0x26, 0x71, 0x34, 0x88, 0xb3, 0xcf, 0x1a, 0x11, 0xf9, 0xc5, 0xef, 0x28, 0xe7, 0x33, 0xed, 0x73,
+	0xfe, 0x68, 0x14, 0x99, 0x5e, 0x70, 0x16, 0x70, 0x22, 0xc4, 0x9a, 0x4c, 0xf7, 0xa1, 0x23, 0x3c,
+	0x9c, 0x24, 0xc4, 0x1f, 0x70, 0x36, 0x29, 0x12, 0xb5, 0x73, 0x9f, 0xcb, 0x26, 0x02, 0xdd, 0x03,
+	0xd0, 0x60, 0x2a, 0x40, 0xf7, 0x5e, 0x4b, 0x79, 0xd4, 0x72, 0x26, 0x7a, 0x2c, 0x21, 0xaa, 0xf7,
+	0xb6, 0x5c, 0xf5, 0xbc, 0x5a, 0x5f, 0x7d, 0x4d, 0x7d, 0x1f, 0x16, 0x1f, 0x93, 0xcc, 0xaa, 0xbe,
+	0x91, 0xd7, 0x80, 0xca, 0x61, 0x95, 0x9c, 0x7c, 0x0c, 0xcd, 0x39, 0x1b, 0xd9, 0x85, 0x44, 0xcb,
+	0xea, 0xe4, 0x85, 0x6e, 0x11, 0xe2, 0x7c, 0x02, 0xbb, 0x45, 0xd6, 0xea, 0x0b, 0xb9, 0x03, 0x35,
+	0xea, 0xe7, 0x94, 0xd4, 0xa8, 0xef, 0xbc, 0x86, 0xee, 0x7c, 0x53, 0x65, 0x21, 0xa7, 0x50, 0x57,
+	0x28, 0x6a, 0xe3, 0xfa, 0x32, 0x74, 0xc0, 0xdb, 0xe9, 0xe9, 0xf7, 0x15, 0x5d, 0xcf, 0xc7, 0x84,
+	0x47, 0x78, 0x5a, 0x5d, 0xf3, 0x21, 0x34, 0x6e, 0x18, 0x8f, 0xb1, 0xcc, 0x85, 0x32, 0xb7, 0x72,
+	0xb6, 0x3f, 0x27, 0x58, 0xc6, 0x38, 0xad, 0x66, 0x3b, 0x02, 0x54, 0x0e, 0xab, 0x3c, 0xe4, 0x5b,
+	0x7d, 0x72, 0xb4, 0x88, 0x30, 0x4e, 0x84, 0xb9, 0x59, 0x88, 0x48, 0x66, 0x39, 0x0e, 0xec, 0x5c,
+	0x8d, 0x82, 0x80, 0x88, 0x6a, 0xdd, 0x77, 0xfe, 0x6c, 0xc0, 0xee, 0x2c, 0xa8, 0xb2, 0x9e, 0x77,
+	0xd3, 0xce, 0x43, 0x68, 0xd0, 0x18, 0x07, 0xaa, 0x2e, 0x55, 0xaf, 0xb6, 0xb2, 0xfe, 0x0d, 0xa9,
+	0x90, 0x2c, 0xe0, 0x38, 0x1e, 0x44, 0x6c, 0xa2, 0xfa, 0xb7, 0xe6, 0x76, 0x66, 0xce, 0x2f, 0xd8,
+	0x24, 0xd3, 0x95, 0x99, 0x6d, 0x36, 0xec, 0x8d, 0xec, 0x5a, 0xcc, 0x1c, 0x67, 0x1f, 0x00, 0xcc,
+	0x07, 0x0d, 0x04, 0xd0, 0xb8, 0x7a, 0xfe, 0x0b, 0xf7, 0x47, 0x4f, 0xba, 0xdf, 0xc8, 0x9e, 0xaf,
+	0x2f, 0xdd, 0xa7, 0x4f, 0xae, 0xbb, 0xc6, 0xf9, 0xbf, 0x5a, 0xb0, 0xf5, 0x63, 0xfd, 0xce, 0x1f,
+	0xa3, 0x57, 0xd0, 0x9a, 0x4d, 0x57, 0xe8, 0xde, 0x42, 0x2f, 0x2c, 0x0f, 0x72, 0xd6, 0x71, 0xd5,
+	0xb2, 0x66, 0xc8, 0x39, 0xf9, 0xed, 0x5f, 0xff, 0xfe, 0xfb, 0xda, 0x5d, 0x74, 0x47, 0x0d, 0xe4,
+	0xe3, 0x47, 0x7d, 0xdd, 0x45, 0x44, 0xf4, 0x5f, 0x67, 0x5f, 0xce, 0x37, 0xe8, 0x6b, 0x80, 0xf9,
+	0xe4, 0x81, 0x16, 0xd3, 0xad, 0x4c, 0x58, 0xd6, 0x49, 0xe5, 0x7a, 0x8e, 0xe7, 0x28, 0xbc, 0x23,
+	0xa7, 0x0a, 0xef, 0xc2, 0x38, 0x43, 0x31, 0xb4, 0x66, 0x13, 0xc1, 0xd2, 0xf9, 0x96, 0x07, 0x12,
+	0xeb, 0xb8, 0x6a, 0x39, 0xc7, 0xbb, 0xaf, 0xf0, 0xde, 0x73, 0x0e, 0x0b, 0xbc, 0xfc, 0x43, 0x5b,
+	0x82, 0x13, 0xd0, 0x29, 0x0f, 0x07, 0xc8, 0x5e, 0x48, 0xb9, 0x66, 0xe8, 0xb0, 0xee, 0xff, 0x87,
+	0x88, 0x1c, 0xf7, 0x58, 0xe1, 0x9a, 0x67, 0x15, 0xb8, 0x88, 0x42, 0xbb, 0x34, 0x3f, 0xa0, 0x25,
+	0xde, 0x56, 0x26, 0x10, 0xcb, 0xae, 0x0e, 0xc8, 0x11, 0xef, 0x28, 0xc4, 0x3d, 0xb4, 0xbb, 0x84,
+	0x88, 0x7e, 0x0d, 0x0d, 0x2d, 0xef, 0xc8, 0x5a, 0x48, 0xb2, 0x30, 0x66, 0x58, 0xef, 0xad, 0x5d,
+	0xcb, 0x73, 0xdf, 0x55, 0xb9, 0xf7, 0x9d, 0x9d, 0x22, 0xb7, 0x50, 0xeb, 0x19, 0x7b, 0xaf, 0xa0,
+	0xa3, 0x83, 0xaf, 0x24, 0x27, 0x38, 0x7e, 0x6b, 0x8c, 0xe2, 0x9b, 0xe3, 0xd8, 0x0a, 0xc3, 0x72,
+	0xbe, 0xb9, 0x88, 0xd1, 0x17, 0x2a, 0xef, 0x85, 0x71, 0xf6, 0xd0, 0x40, 0x37, 0x00, 0x73, 0x8d,
+	0x47, 0xeb, 0x7a, 0xbb, 0xf4, 0x8d, 0xb0, 0x4e, 0x2a, 0xd7, 0xab, 0x28, 0xcb, 0x55, 0x1f, 0x11,
+	0xd8, 0x2a, 0xc2, 0xd1, 0xd1, 0xda, 0x2c, 0x05, 0xc6, 0xbd, 0x8a, 0xd5, 0x1c, 0xe1, 0x48, 0x21,
+	0x1c, 0xa2, 0x83, 0x25, 0x84, 0xfe, 0x6b, 0xea, 0xbf, 0x41, 0xbf, 0x02, 0x98, 0x4b, 0xf5, 0xea,
+	0x71, 0x16, 0x35, 0xdc, 0x3a, 0xe8, 0xe9, 0x7f, 0x9d, 0x7b, 0x38, 0xa5, 0xbd, 0xcf, 0xa5, 0x4c,
+	0x3f, 0x63, 0xfe, 0x74, 0xf5, 0x0c, 0x2c, 0x4f, 0xa7, 0xb9, 0xca, 0x15, 0x7a, 0x35, 0xf9, 0xa2,
+	0xc2, 0x5b, 0x27, 0x95, 0xeb, 0x55, 0x5c, 0x85, 0x79, 0xe6, 0x2f, 0xa1, 0x99, 0xcb, 0x2e, 0x5a,
+	0x7a, 0xbf, 0x0b, 0x8a, 0x6d, 0x1d, 0xad, 0x5f, 0xac, 0x4a, 0x2f, 0x74, 0xc0, 0x67, 0xff, 0xa8,
+	0xfd, 0xee, 0xf2, 0x6f, 0x35, 0xf4, 0x25, 0x74, 0x0b, 0xfd, 0xb3, 0xaf, 0xf4, 0x6f, 0x0f, 0xce,
+	0xe3, 0x92, 0x26, 0x7e, 0x90, 0xfd, 0x96, 0x20, 0x2e, 0xfa, 0xfd, 0x80, 0xca, 0x70, 0x34, 0xec,
+	0x79, 0x2c, 0xee, 0x7f, 0xc5, 0x42, 0x9c, 0xf8, 0x7c, 0xda, 0x2f, 0x80, 0x2d, 0x54, 0xb8, 0x7e,
+	0x18, 0xc4, 0x98, 0x46, 0x59, 0xd4, 0xf9, 0xc6, 0xa3, 0xde, 0xc3, 0x33, 0xc3, 0x38, 0xef, 0xe2,
+	0x34, 0x8d, 0xa8, 0xa7, 0xa6, 0xd9, 0xfe, 0x57, 0x82, 0x25, 0x17, 0x2b, 0x1e, 0xf7, 0x07, 0xb0,
+	0xf1, 0xe9, 0xc3, 0x4f, 0xd1, 0x77, 0xe0, 0x81, 0x4b, 0xe4, 0x88, 0x27, 0xc4, 0xb7, 0x27, 0x21,
+	0x49, 0x6c, 0x19, 0x12, 0x5b, 0x5f, 0x37, 0x5b, 0xeb, 0x99, 0x4d, 0x85, 0x9d, 0x30, 0x69, 0xdf,
+	0xb0, 0x51, 0xe2, 0xf7, 0x50, 0x03, 0x36, 0xff, 0x50, 0x33, 0x9a, 0xee, 0x65, 0xb6, 0xff, 0x21,
+	0xba, 0x80, 0xef, 0x2e, 0xee, 0xc7, 0x36, 0xd7, 0x2c, 0x65, 0xfb, 0x68, 0x32, 0xc6, 0x11, 0xf5,
+	0x6d, 0xc6, 0xed, 0x98, 0x0a, 0x41, 0x93, 0xc0, 0x4e, 0x31, 0xc7, 0x31, 0x91, 0x84, 0x0b, 0x7e,
+	0x0d, 0x87, 0x33, 0x22, 0x1e, 0x33, 0x6f, 0x14, 0xcf, 0x26, 0xf0, 0x8b, 0xff, 0x86, 0x82, 0xfe,
+	0x30, 0x62, 0xc3, 0x7e, 0x8c, 0x85, 0x24, 0xbc, 0xef, 0x3e, 0xb9, 0x7c, 0xfc, 0xec, 0x49, 0x2f,
+	0xf6, 0x7f, 0x59, 0x1b, 0x3f, 0x1a, 0x36, 0xd4, 0xef, 0x2a, 0x9f, 0xfc, 0x7b, 0x00, 0x60, 0xde,
+	0x03, 0x67, 0xdc, 0x11, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
 
 // Finder2DClient is the client API for Finder2D service.
 //
@@ -737,9 +1668,16 @@ const _ = grpc.SupportPackageIsVersion4
 type Finder2DClient interface {
 	GetMatrix(ctx context.Context, in *GetMatrixRequest, opts ...grpc.CallOption) (*GetMatrixResponse, error)
 	LoadMatrix(ctx context.Context, in *LoadMatrixRequest, opts ...grpc.CallOption) (*LoadMatrixResponse, error)
+	AddTarget(ctx context.Context, in *AddTargetRequest, opts ...grpc.CallOption) (*AddTargetResponse, error)
+	RemoveTarget(ctx context.Context, in *RemoveTargetRequest, opts ...grpc.CallOption) (*RemoveTargetResponse, error)
+	ListTargets(ctx context.Context, in *ListTargetsRequest, opts ...grpc.CallOption) (*ListTargetsResponse, error)
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	SearchStream(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (Finder2D_SearchStreamClient, error)
 	GetMatches(ctx context.Context, in *GetMatchesRequest, opts ...grpc.CallOption) (*GetMatchesResponse, error)
 	GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*GetMatchResponse, error)
+	GetOverlay(ctx context.Context, in *GetOverlayRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	GetHeatmap(ctx context.Context, in *GetHeatmapRequest, opts ...grpc.CallOption) (*GetHeatmapResponse, error)
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
 }
 
 type finder2DClient struct {
@@ -768,6 +1706,33 @@ func (c *finder2DClient) LoadMatrix(ctx context.Context, in *LoadMatrixRequest,
 	return out, nil
 }
 
+func (c *finder2DClient) AddTarget(ctx context.Context, in *AddTargetRequest, opts ...grpc.CallOption) (*AddTargetResponse, error) {
+	out := new(AddTargetResponse)
+	err := c.cc.Invoke(ctx, "/finder2d.v1.Finder2D/AddTarget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finder2DClient) RemoveTarget(ctx context.Context, in *RemoveTargetRequest, opts ...grpc.CallOption) (*RemoveTargetResponse, error) {
+	out := new(RemoveTargetResponse)
+	err := c.cc.Invoke(ctx, "/finder2d.v1.Finder2D/RemoveTarget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finder2DClient) ListTargets(ctx context.Context, in *ListTargetsRequest, opts ...grpc.CallOption) (*ListTargetsResponse, error) {
+	out := new(ListTargetsResponse)
+	err := c.cc.Invoke(ctx, "/finder2d.v1.Finder2D/ListTargets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *finder2DClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
 	out := new(SearchResponse)
 	err := c.cc.Invoke(ctx, "/finder2d.v1.Finder2D/Search", in, out, opts...)
@@ -777,6 +1742,38 @@ func (c *finder2DClient) Search(ctx context.Context, in *SearchRequest, opts ...
 	return out, nil
 }
 
+func (c *finder2DClient) SearchStream(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (Finder2D_SearchStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Finder2D_serviceDesc.Streams[0], "/finder2d.v1.Finder2D/SearchStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &finder2DSearchStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Finder2D_SearchStreamClient interface {
+	Recv() (*SearchProgress, error)
+	grpc.ClientStream
+}
+
+type finder2DSearchStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *finder2DSearchStreamClient) Recv() (*SearchProgress, error) {
+	m := new(SearchProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *finder2DClient) GetMatches(ctx context.Context, in *GetMatchesRequest, opts ...grpc.CallOption) (*GetMatchesResponse, error) {
 	out := new(GetMatchesResponse)
 	err := c.cc.Invoke(ctx, "/finder2d.v1.Finder2D/GetMatches", in, out, opts...)
@@ -795,13 +1792,47 @@ func (c *finder2DClient) GetMatch(ctx context.Context, in *GetMatchRequest, opts
 	return out, nil
 }
 
+func (c *finder2DClient) GetOverlay(ctx context.Context, in *GetOverlayRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/finder2d.v1.Finder2D/GetOverlay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finder2DClient) GetHeatmap(ctx context.Context, in *GetHeatmapRequest, opts ...grpc.CallOption) (*GetHeatmapResponse, error) {
+	out := new(GetHeatmapResponse)
+	err := c.cc.Invoke(ctx, "/finder2d.v1.Finder2D/GetHeatmap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finder2DClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, "/finder2d.v1.Finder2D/Suggest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Finder2DServer is the server API for Finder2D service.
 type Finder2DServer interface {
 	GetMatrix(context.Context, *GetMatrixRequest) (*GetMatrixResponse, error)
 	LoadMatrix(context.Context, *LoadMatrixRequest) (*LoadMatrixResponse, error)
+	AddTarget(context.Context, *AddTargetRequest) (*AddTargetResponse, error)
+	RemoveTarget(context.Context, *RemoveTargetRequest) (*RemoveTargetResponse, error)
+	ListTargets(context.Context, *ListTargetsRequest) (*ListTargetsResponse, error)
 	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	SearchStream(*SearchRequest, Finder2D_SearchStreamServer) error
 	GetMatches(context.Context, *GetMatchesRequest) (*GetMatchesResponse, error)
 	GetMatch(context.Context, *GetMatchRequest) (*GetMatchResponse, error)
+	GetOverlay(context.Context, *GetOverlayRequest) (*httpbody.HttpBody, error)
+	GetHeatmap(context.Context, *GetHeatmapRequest) (*GetHeatmapResponse, error)
+	Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error)
 }
 
 // UnimplementedFinder2DServer can be embedded to have forward compatible implementations.
@@ -814,15 +1845,36 @@ func (*UnimplementedFinder2DServer) GetMatrix(ctx context.Context, req *GetMatri
 func (*UnimplementedFinder2DServer) LoadMatrix(ctx context.Context, req *LoadMatrixRequest) (*LoadMatrixResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoadMatrix not implemented")
 }
+func (*UnimplementedFinder2DServer) AddTarget(ctx context.Context, req *AddTargetRequest) (*AddTargetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTarget not implemented")
+}
+func (*UnimplementedFinder2DServer) RemoveTarget(ctx context.Context, req *RemoveTargetRequest) (*RemoveTargetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTarget not implemented")
+}
+func (*UnimplementedFinder2DServer) ListTargets(ctx context.Context, req *ListTargetsRequest) (*ListTargetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTargets not implemented")
+}
 func (*UnimplementedFinder2DServer) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (*UnimplementedFinder2DServer) SearchStream(req *SearchRequest, srv Finder2D_SearchStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method SearchStream not implemented")
+}
 func (*UnimplementedFinder2DServer) GetMatches(ctx context.Context, req *GetMatchesRequest) (*GetMatchesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMatches not implemented")
 }
 func (*UnimplementedFinder2DServer) GetMatch(ctx context.Context, req *GetMatchRequest) (*GetMatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMatch not implemented")
 }
+func (*UnimplementedFinder2DServer) GetOverlay(ctx context.Context, req *GetOverlayRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOverlay not implemented")
+}
+func (*UnimplementedFinder2DServer) GetHeatmap(ctx context.Context, req *GetHeatmapRequest) (*GetHeatmapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeatmap not implemented")
+}
+func (*UnimplementedFinder2DServer) Suggest(ctx context.Context, req *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
 
 func RegisterFinder2DServer(s *grpc.Server, srv Finder2DServer) {
 	s.RegisterService(&_Finder2D_serviceDesc, srv)
@@ -864,6 +1916,60 @@ func _Finder2D_LoadMatrix_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Finder2D_AddTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Finder2DServer).AddTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/finder2d.v1.Finder2D/AddTarget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Finder2DServer).AddTarget(ctx, req.(*AddTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Finder2D_RemoveTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Finder2DServer).RemoveTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/finder2d.v1.Finder2D/RemoveTarget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Finder2DServer).RemoveTarget(ctx, req.(*RemoveTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Finder2D_ListTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTargetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Finder2DServer).ListTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/finder2d.v1.Finder2D/ListTargets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Finder2DServer).ListTargets(ctx, req.(*ListTargetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Finder2D_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchRequest)
 	if err := dec(in); err != nil {
@@ -882,6 +1988,27 @@ func _Finder2D_Search_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Finder2D_SearchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Finder2DServer).SearchStream(m, &finder2DSearchStreamServer{stream})
+}
+
+type Finder2D_SearchStreamServer interface {
+	Send(*SearchProgress) error
+	grpc.ServerStream
+}
+
+type finder2DSearchStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *finder2DSearchStreamServer) Send(m *SearchProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Finder2D_GetMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMatchesRequest)
 	if err := dec(in); err != nil {
@@ -918,6 +2045,60 @@ func _Finder2D_GetMatch_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Finder2D_GetOverlay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOverlayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Finder2DServer).GetOverlay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/finder2d.v1.Finder2D/GetOverlay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Finder2DServer).GetOverlay(ctx, req.(*GetOverlayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Finder2D_GetHeatmap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeatmapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Finder2DServer).GetHeatmap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/finder2d.v1.Finder2D/GetHeatmap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Finder2DServer).GetHeatmap(ctx, req.(*GetHeatmapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Finder2D_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Finder2DServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/finder2d.v1.Finder2D/Suggest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Finder2DServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Finder2D_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "finder2d.v1.Finder2D",
 	HandlerType: (*Finder2DServer)(nil),
@@ -930,6 +2111,18 @@ var _Finder2D_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LoadMatrix",
 			Handler:    _Finder2D_LoadMatrix_Handler,
 		},
+		{
+			MethodName: "AddTarget",
+			Handler:    _Finder2D_AddTarget_Handler,
+		},
+		{
+			MethodName: "RemoveTarget",
+			Handler:    _Finder2D_RemoveTarget_Handler,
+		},
+		{
+			MethodName: "ListTargets",
+			Handler:    _Finder2D_ListTargets_Handler,
+		},
 		{
 			MethodName: "Search",
 			Handler:    _Finder2D_Search_Handler,
@@ -942,7 +2135,25 @@ var _Finder2D_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetMatch",
 			Handler:    _Finder2D_GetMatch_Handler,
 		},
+		{
+			MethodName: "GetOverlay",
+			Handler:    _Finder2D_GetOverlay_Handler,
+		},
+		{
+			MethodName: "GetHeatmap",
+			Handler:    _Finder2D_GetHeatmap_Handler,
+		},
+		{
+			MethodName: "Suggest",
+			Handler:    _Finder2D_Suggest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchStream",
+			Handler:       _Finder2D_SearchStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",
 }
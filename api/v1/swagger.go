@@ -25,6 +25,40 @@ var Swagger = []byte(`
     "application/json"
   ],
   "paths": {
+    "/api/v1/heatmap": {
+      "get": {
+        "operationId": "GetHeatmap",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1GetHeatmapResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the target matrix is not found.",
+            "schema": {
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "api",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Finder2D"
+        ]
+      }
+    },
     "/api/v1/matches": {
       "get": {
         "operationId": "GetMatches",
@@ -188,6 +222,46 @@ var Swagger = []byte(`
         ]
       }
     },
+    "/api/v1/overlay": {
+      "get": {
+        "operationId": "GetOverlay",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/apiHttpBody"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the target matrix is not found.",
+            "schema": {
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "api",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "format",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Finder2D"
+        ]
+      }
+    },
     "/api/v1/search": {
       "post": {
         "operationId": "Search",
@@ -223,9 +297,297 @@ var Swagger = []byte(`
           "Finder2D"
         ]
       }
+    },
+    "/api/v1/search/stream": {
+      "post": {
+        "operationId": "SearchStream",
+        "responses": {
+          "200": {
+            "description": "A successful response.(streaming responses)",
+            "schema": {
+              "$ref": "#/x-stream-definitions/v1SearchProgress"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the target matrix is not found.",
+            "schema": {
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/v1SearchRequest"
+            }
+          }
+        ],
+        "tags": [
+          "Finder2D"
+        ]
+      }
+    },
+    "/api/v1/suggest": {
+      "get": {
+        "operationId": "Suggest",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1SuggestResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the target matrix is not found.",
+            "schema": {
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "api",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Finder2D"
+        ]
+      }
+    },
+    "/api/v1/targets": {
+      "get": {
+        "operationId": "ListTargets",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1ListTargetsResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the target matrix is not found.",
+            "schema": {
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "api",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Finder2D"
+        ]
+      }
+    },
+    "/api/v1/targets/{name}": {
+      "delete": {
+        "operationId": "RemoveTarget",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1RemoveTargetResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the target matrix is not found.",
+            "schema": {
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "api",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Finder2D"
+        ]
+      },
+      "post": {
+        "operationId": "AddTarget",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1AddTargetResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the target matrix is not found.",
+            "schema": {
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/v1AddTargetRequest"
+            }
+          }
+        ],
+        "tags": [
+          "Finder2D"
+        ]
+      }
     }
   },
   "definitions": {
+    "apiHttpBody": {
+      "type": "object",
+      "properties": {
+        "content_type": {
+          "type": "string",
+          "description": "The HTTP Content-Type header value specifying the content type of the body."
+        },
+        "data": {
+          "type": "string",
+          "format": "byte",
+          "description": "The HTTP request/response body as raw binary."
+        },
+        "extensions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/protobufAny"
+          },
+          "description": "Application specific response metadata. Must be set in the first response\nfor streaming APIs."
+        }
+      },
+      "description": "Message that represents an arbitrary HTTP body. It should only be used for\npayload formats that can't be represented as JSON, such as raw binary or\nan HTML page.\n\n\nThis message can be used both in streaming and non-streaming API methods in\nthe request as well as the response.\n\nIt can be used as a top-level request field, which is convenient if one\nwants to extract parameters from either the URL or HTTP template into the\nrequest fields and also want access to the raw HTTP body.\n\nExample:\n\n    message GetResourceRequest {\n      // A unique request id.\n      string request_id = 1;\n\n      // The raw HTTP body is bound to this field.\n      google.api.HttpBody http_body = 2;\n    }\n\n    service ResourceService {\n      rpc GetResource(GetResourceRequest) returns (google.api.HttpBody);\n      rpc UpdateResource(google.api.HttpBody) returns\n      (google.protobuf.Empty);\n    }\n\nExample with streaming methods:\n\n    service CaldavService {\n      rpc GetCalendar(stream google.api.HttpBody)\n        returns (stream google.api.HttpBody);\n      rpc UpdateCalendar(stream google.api.HttpBody)\n        returns (stream google.api.HttpBody);\n    }\n\nUse of this type only changes how the request and response bodies are\nhandled, all other features will continue to work unchanged."
+    },
+    "protobufAny": {
+      "type": "object",
+      "properties": {
+        "type_url": {
+          "type": "string"
+        },
+        "value": {
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "runtimeStreamError": {
+      "type": "object",
+      "properties": {
+        "grpc_code": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "http_code": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "message": {
+          "type": "string"
+        },
+        "http_status": {
+          "type": "string"
+        },
+        "details": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/protobufAny"
+          }
+        }
+      }
+    },
+    "v1AddTargetRequest": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "matrix": {
+          "$ref": "#/definitions/v1Matrix"
+        }
+      }
+    },
+    "v1AddTargetResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        }
+      }
+    },
+    "v1GetHeatmapResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "width": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "height": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "scores": {
+          "type": "array",
+          "items": {
+            "type": "number",
+            "format": "float"
+          }
+        }
+      }
+    },
     "v1GetMatchResponse": {
       "type": "object",
       "properties": {
@@ -268,6 +630,20 @@ var Swagger = []byte(`
         }
       }
     },
+    "v1ListTargetsResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "targets": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/v1Target"
+          }
+        }
+      }
+    },
     "v1LoadMatrixRequest": {
       "type": "object",
       "properties": {
@@ -279,6 +655,16 @@ var Swagger = []byte(`
         },
         "matrix": {
           "$ref": "#/definitions/v1Matrix"
+        },
+        "alphabet": {
+          "type": "string"
+        },
+        "data": {
+          "type": "string",
+          "format": "byte"
+        },
+        "threshold": {
+          "type": "string"
         }
       }
     },
@@ -304,6 +690,32 @@ var Swagger = []byte(`
         "percentage": {
           "type": "number",
           "format": "float"
+        },
+        "orientation": {
+          "type": "string"
+        },
+        "width": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "height": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "scale": {
+          "type": "number",
+          "format": "float"
+        },
+        "target": {
+          "type": "string"
+        },
+        "p_value": {
+          "type": "number",
+          "format": "double"
+        },
+        "visible": {
+          "type": "number",
+          "format": "float"
         }
       }
     },
@@ -331,6 +743,38 @@ var Swagger = []byte(`
       ],
       "default": "SOURCE"
     },
+    "v1RemoveTargetResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        }
+      }
+    },
+    "v1SearchProgress": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "scanned_rows": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "total_rows": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "done": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "total_matches": {
+          "type": "integer",
+          "format": "int32"
+        }
+      }
+    },
     "v1SearchRequest": {
       "type": "object",
       "properties": {
@@ -344,6 +788,72 @@ var Swagger = []byte(`
         "delta": {
           "type": "integer",
           "format": "int32"
+        },
+        "strategy": {
+          "type": "string"
+        },
+        "workers": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "any_orientation": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "scales": {
+          "type": "array",
+          "items": {
+            "type": "number",
+            "format": "float"
+          }
+        },
+        "metric": {
+          "type": "string"
+        },
+        "weights": {
+          "type": "array",
+          "items": {
+            "type": "number",
+            "format": "float"
+          }
+        },
+        "reduction": {
+          "type": "string"
+        },
+        "iou_threshold": {
+          "type": "number",
+          "format": "float"
+        },
+        "top_k": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "comparison": {
+          "type": "string"
+        },
+        "tolerance": {
+          "type": "number",
+          "format": "float"
+        },
+        "significance": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "max_p_value": {
+          "type": "number",
+          "format": "double"
+        },
+        "border": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "min_visible": {
+          "type": "number",
+          "format": "float"
+        },
+        "wrap": {
+          "type": "boolean",
+          "format": "boolean"
         }
       }
     },
@@ -358,6 +868,67 @@ var Swagger = []byte(`
           "format": "int32"
         }
       }
+    },
+    "v1SuggestResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "percentage": {
+          "type": "number",
+          "format": "float"
+        },
+        "delta": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "images": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "histogram_low": {
+          "type": "number",
+          "format": "float"
+        },
+        "histogram": {
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int32"
+          }
+        }
+      }
+    },
+    "v1Target": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "width": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "height": {
+          "type": "integer",
+          "format": "int32"
+        }
+      }
+    }
+  },
+  "x-stream-definitions": {
+    "v1SearchProgress": {
+      "type": "object",
+      "properties": {
+        "result": {
+          "$ref": "#/definitions/v1SearchProgress"
+        },
+        "error": {
+          "$ref": "#/definitions/runtimeStreamError"
+        }
+      },
+      "title": "Stream result of v1SearchProgress"
     }
   },
   "externalDocs": {
@@ -0,0 +1,168 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"math/bits"
+)
+
+const wordSize = 64
+
+// bitRow is a matrix row packed in 64 bits words, the cell `x` is the bit
+// `x % 64` of the word `x / 64`
+type bitRow []uint64
+
+func init() {
+	// the simple strategy compares the bit packed rows, this name is kept for
+	// the clients that select it
+	RegisterSearcher("bitpacked", simpleSearcher{})
+}
+
+func packRow(row []int) bitRow {
+	r := make(bitRow, (len(row)+wordSize-1)/wordSize)
+	for x, v := range row {
+		if v == 1 {
+			r[x/wordSize] |= 1 << uint(x%wordSize)
+		}
+	}
+	return r
+}
+
+// packCareRow returns the packed row of the cells not ignored
+func packCareRow(row []int) bitRow {
+	r := make(bitRow, (len(row)+wordSize-1)/wordSize)
+	for x, v := range row {
+		if v != Ignored {
+			r[x/wordSize] |= 1 << uint(x%wordSize)
+		}
+	}
+	return r
+}
+
+// fullRow returns the packed row of the given width with all the cells set
+func fullRow(width int) bitRow {
+	r := make(bitRow, (width+wordSize-1)/wordSize)
+	for k := range r {
+		r[k] = ^uint64(0)
+	}
+	if len(r) != 0 {
+		r[len(r)-1] = lastWordMask(width)
+	}
+	return r
+}
+
+// word returns the 64 cells of the row starting at the cell `start`, the cells
+// out of the row are zero
+func (r bitRow) word(start int) uint64 {
+	i := start / wordSize
+	off := uint(start % wordSize)
+	w := r[i] >> off
+	if off != 0 && i+1 < len(r) {
+		w |= r[i+1] << (wordSize - off)
+	}
+	return w
+}
+
+// lastWordMask returns the mask for the valid cells of the last word of a row
+// with the given width
+func lastWordMask(width int) uint64 {
+	if n := uint(width % wordSize); n != 0 {
+		return 1<<n - 1
+	}
+	return ^uint64(0)
+}
+
+// confusionAt returns the confusion counts of the area of the matrix starting
+// at (x,y) with the target size compared with the target, weighted by the
+// target weights if any. The area has to be inside the matrix. The cells of
+// matrixes with more than two levels or values are compared with the
+// comparison and tolerance of the given parameters
+func (m *Matrix) confusionAt(x, y int, target *Matrix, params SearchParams) Confusion {
+	if len(target.bits) == 0 {
+		return Confusion{}
+	}
+	if !m.binary() || !target.binary() {
+		return m.confusionCellsAt(x, y, target, params)
+	}
+	if target.classes == nil && target.weights == nil {
+		tp, tn, fp, fn := m.countAt(x, y, target, nil)
+		return Confusion{TP: float64(tp), TN: float64(tn), FP: float64(fp), FN: float64(fn)}
+	}
+
+	var c Confusion
+	for _, class := range target.classes {
+		tp, tn, fp, fn := m.countAt(x, y, target, class.mask)
+		c.TP += class.weight * float64(tp)
+		c.TN += class.weight * float64(tn)
+		c.FP += class.weight * float64(fp)
+		c.FN += class.weight * float64(fn)
+	}
+	return c
+}
+
+// countAt returns the number of cells that are ones in both, zeros in both,
+// ones only in the matrix and ones only in the target, comparing the target
+// with the area of the matrix starting at (x,y). The cells ignored in any of
+// them are not counted, and if the mask is given only the target cells in the
+// mask are counted
+func (m *Matrix) countAt(x, y int, target *Matrix, mask []bitRow) (tp, tn, fp, fn int) {
+	words := len(target.bits[0])
+	last := lastWordMask(target.maxX)
+
+	if m.care == nil && target.care == nil && mask == nil {
+		var diff, ones int
+		for yi, trow := range target.bits {
+			srow := m.bits[y+yi]
+			for k := 0; k < words; k++ {
+				s := srow.word(x + k*wordSize)
+				if k == words-1 {
+					s &= last
+				}
+				diff += bits.OnesCount64(s ^ trow[k])
+				ones += bits.OnesCount64(s)
+			}
+		}
+		tp = (ones + target.ones - diff) / 2
+		fp, fn = ones-tp, target.ones-tp
+		return tp, target.maxX*target.maxY - tp - fp - fn, fp, fn
+	}
+
+	for yi, trow := range target.bits {
+		srow := m.bits[y+yi]
+		for k := 0; k < words; k++ {
+			c := ^uint64(0)
+			if k == words-1 {
+				c = last
+			}
+			if target.care != nil {
+				c &= target.care[yi][k]
+			}
+			if mask != nil {
+				c &= mask[yi][k]
+			}
+			if m.care != nil {
+				c &= m.care[y+yi].word(x + k*wordSize)
+			}
+			s, t := srow.word(x+k*wordSize)&c, trow[k]&c
+			tp += bits.OnesCount64(s & t)
+			fp += bits.OnesCount64(s &^ t)
+			fn += bits.OnesCount64(t &^ s)
+			tn += bits.OnesCount64(c &^ (s | t))
+		}
+	}
+	return tp, tn, fp, fn
+}
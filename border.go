@@ -0,0 +1,111 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import "math"
+
+// DefaultMinVisible is the default minimum fraction of the target inside the
+// source of the partial matches at the source borders
+const DefaultMinVisible = 0.5
+
+// searchSource returns the source to search the targets up to the given size
+// in. With `Border` it's the source surrounded by ignored cells, so a target
+// may overhang any border of the source by all but one of its columns and
+// rows, and only the part of the target inside the source is compared. With
+// `Wrap` it's the source extended to the right and the bottom with its first
+// columns and rows, so a target may cross the right and bottom borders if it
+// fits in the source. Both modes cannot be used together, the search fails
+func (f *Finder2D) searchSource(width, height int) *Matrix {
+	maxX, maxY := f.Source.Size()
+	switch {
+	case width+height == 0:
+		return f.Source
+	case f.Border:
+		return f.Source.pad(width-1, height-1)
+	case f.Wrap && width <= maxX && height <= maxY:
+		return f.Source.wrap(width-1, height-1)
+	}
+	return f.Source
+}
+
+// borderMatches moves the matches of the target found in the source padded
+// with `dx` columns and `dy` rows to the coordinates of the source, negative if
+// the target overhangs the left or top border, and sets the visible fraction
+// of every match. The matches with a visible fraction lower than `MinVisible`
+// are removed
+func (f *Finder2D) borderMatches(matches []Match, target *Matrix, dx, dy int) []Match {
+	width, height := target.Size()
+	maxX, maxY := f.Source.Size()
+	kept := matches[:0]
+	for _, m := range matches {
+		m.X, m.Y = m.X-dx, m.Y-dy
+		m.Visible = visibleFraction(m.X, m.Y, width, height, maxX, maxY)
+		if m.Visible > 0 && m.Visible >= f.MinVisible {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// visibleFraction returns the fraction of the area of the given size at (x,y)
+// inside a matrix of size (maxX,maxY)
+func visibleFraction(x, y, w, h, maxX, maxY int) float64 {
+	if w*h == 0 {
+		return 0
+	}
+	vw := math.Min(float64(x+w), float64(maxX)) - math.Max(float64(x), 0)
+	vh := math.Min(float64(y+h), float64(maxY)) - math.Max(float64(y), 0)
+	if vw <= 0 || vh <= 0 {
+		return 0
+	}
+	return vw * vh / float64(w*h)
+}
+
+// pad returns a copy of the matrix with `dx` ignored columns at the left and
+// the right, and `dy` ignored rows at the top and the bottom
+func (m *Matrix) pad(dx, dy int) *Matrix {
+	w, h := m.maxX+2*dx, m.maxY+2*dy
+	content := make([][]int, h)
+	for y := range content {
+		content[y] = make([]int, w)
+		for x := range content[y] {
+			content[y][x] = Ignored
+		}
+		if sy := y - dy; sy >= 0 && sy < m.maxY {
+			for x := 0; x < m.maxX; x++ {
+				content[y][dx+x] = m.Cell(x, sy)
+			}
+		}
+	}
+
+	pm := &Matrix{}
+	pm.setContent(content, w, h, m.levels)
+	if m.values != nil {
+		values := make([][]float64, h)
+		for y := range values {
+			values[y] = make([]float64, w)
+			for x := range values[y] {
+				values[y][x] = math.NaN()
+			}
+			if sy := y - dy; sy >= 0 && sy < m.maxY {
+				copy(values[y][dx:], m.values[sy])
+			}
+		}
+		pm.setValues(values, m.lo, m.hi)
+	}
+	return pm
+}
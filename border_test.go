@@ -0,0 +1,121 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// borderSource returns a source of zeros with the target of the finder cut
+// off by the right border at (30,5) and by the left and bottom borders at
+// (-5,20)
+func borderSource(t *testing.T, f *Finder2D) *Matrix {
+	content := make([][]int, 30)
+	for y := range content {
+		content[y] = make([]int, 40)
+	}
+	for ty, row := range f.Target.Content() {
+		for tx, v := range row {
+			if x := 30 + tx; x < 40 {
+				content[5+ty][x] = v
+			}
+			if x := -5 + tx; x >= 0 && 20+ty < 30 {
+				content[20+ty][x] = v
+			}
+		}
+	}
+	return newTestMatrix(t, content)
+}
+
+func TestFinder2D_Search_border(t *testing.T) {
+	f := loadTestFinder(t, 99, 1)
+	f.Source = borderSource(t, f)
+
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if len(f.Matches) != 0 {
+		t.Fatalf("Finder2D.Search() without border = %v, want no matches", f.Matches)
+	}
+
+	tests := []struct {
+		name       string
+		minVisible float64
+		want       []Match
+	}{
+		{"default", DefaultMinVisible, []Match{
+			{X: 30, Y: 5, Percentage: 100, Visible: 10.0 / 15},
+		}},
+		{"less visible", 0.4, []Match{
+			{X: 30, Y: 5, Percentage: 100, Visible: 10.0 / 15},
+			{X: -5, Y: 20, Percentage: 100, Visible: 100.0 / 225},
+		}},
+	}
+	for _, tt := range tests {
+		for _, strategy := range Searchers() {
+			t.Run(tt.name+" "+strategy, func(t *testing.T) {
+				f.Border, f.MinVisible, f.Strategy = true, tt.minVisible, strategy
+				if err := f.Search(); err != nil {
+					t.Fatalf("Finder2D.Search() error = %v", err)
+				}
+				if !reflect.DeepEqual(f.Matches, tt.want) {
+					t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, tt.want)
+				}
+			})
+		}
+	}
+
+	// the clipped boxes are rendered inside the source
+	f.Strategy, f.MinVisible = DefaultStrategy, 0.4
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	out := f.Matrix()
+	matched := strings.Count(out, unoMatch) + strings.Count(out, ceroMatch)
+	if want := 10*15 + 10*10; matched != want {
+		t.Errorf("Finder2D.Matrix() has %d cells in the match areas, want %d", matched, want)
+	}
+
+	f.MinVisible = 2
+	if err := f.Search(); err == nil {
+		t.Errorf("Finder2D.Search() with minimum visible fraction 2 error = nil, want an error")
+	}
+}
+
+func Test_visibleFraction(t *testing.T) {
+	tests := []struct {
+		name       string
+		x, y, w, h int
+		want       float64
+	}{
+		{"inside", 2, 2, 4, 4, 1},
+		{"right", 8, 0, 4, 4, 0.5},
+		{"bottom right", 8, 8, 4, 4, 0.25},
+		{"top left", -3, -1, 4, 4, 0.1875},
+		{"outside", 10, 0, 4, 4, 0},
+		{"empty", 0, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := visibleFraction(tt.x, tt.y, tt.w, tt.h, 10, 10); got != tt.want {
+				t.Errorf("visibleFraction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
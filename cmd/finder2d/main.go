@@ -20,24 +20,55 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 
+	"github.com/johandry/finder2d"
 	"github.com/johandry/finder2d/pkg/cli"
 	"github.com/johandry/finder2d/pkg/server"
 )
 
 type config struct {
 	sourceFileName string
-	targetFileName string
+	targets        *listFlag
 	zero           string
 	one            string
+	ignore         string
+	alphabet       string
+	comparison     string
+	values         bool
+	tolerance      float64
+	threshold      string
 	percentage     float64
 	delta          int
+	strategy       string
+	workers        int
+	orientations   bool
+	scales         string
+	metric         string
+	weights        string
+	reduction      string
+	iou            float64
+	top            int
+	significance   bool
+	maxPValue      float64
+	border         bool
+	minVisible     float64
+	wrap           bool
+	progress       bool
 	output         string
+	columns        string
+	heatmap        string
+	out            string
 	port           string
+	command        string
 }
 
+// suggestCommand is the command to print the suggested percentage and delta
+// instead of the matches
+const suggestCommand = "suggest"
+
 const envPrefix = "FINDER2D"
 
 func main() {
@@ -45,18 +76,58 @@ func main() {
 	opts := &config{
 		zero:       " ",
 		one:        "+",
+		ignore:     "?",
 		percentage: 50.0,
 		delta:      1,
+		strategy:   finder2d.DefaultStrategy,
+		workers:    runtime.NumCPU(),
+		iou:        finder2d.DefaultIoUThreshold,
+		minVisible: finder2d.DefaultMinVisible,
 		output:     "json",
 		port:       "8080",
 	}
 	opts.Init().Read()
 
 	var err error
-	if serverMode := len(opts.targetFileName) == 0; serverMode {
-		err = server.Serve(opts.port, opts.sourceFileName, opts.zero, opts.one)
+	if len(opts.command) != 0 && opts.command != suggestCommand {
+		err = fmt.Errorf("unknown command %q. Available commands are: %s", opts.command, suggestCommand)
+	} else if serverMode := len(opts.targets.values) == 0 && len(opts.command) == 0; serverMode {
+		err = server.Serve(opts.port, opts.sourceFileName, opts.zero, opts.one, opts.ignore, opts.alphabet, opts.values, strings.ToLower(opts.threshold))
 	} else {
-		err = cli.Execute(opts.sourceFileName, opts.targetFileName, opts.zero, opts.one, opts.percentage, opts.delta, strings.ToLower(opts.output))
+		err = cli.Execute(cli.Options{
+			SourceFileName: opts.sourceFileName,
+			TargetFiles:    opts.targets.values,
+			Zero:           opts.zero,
+			One:            opts.one,
+			Ignore:         opts.ignore,
+			Alphabet:       opts.alphabet,
+			Comparison:     strings.ToLower(opts.comparison),
+			Values:         opts.values,
+			Tolerance:      opts.tolerance,
+			Threshold:      strings.ToLower(opts.threshold),
+			Percentage:     opts.percentage,
+			Delta:          opts.delta,
+			Strategy:       strings.ToLower(opts.strategy),
+			Workers:        opts.workers,
+			AnyOrientation: opts.orientations,
+			Scales:         opts.scales,
+			Metric:         strings.ToLower(opts.metric),
+			WeightsFile:    opts.weights,
+			Reduction:      strings.ToLower(opts.reduction),
+			IoUThreshold:   opts.iou,
+			TopK:           opts.top,
+			Significance:   opts.significance,
+			MaxPValue:      opts.maxPValue,
+			Border:         opts.border,
+			MinVisible:     opts.minVisible,
+			Wrap:           opts.wrap,
+			Progress:       opts.progress,
+			Format:         strings.ToLower(opts.output),
+			Columns:        opts.columns,
+			Heatmap:        strings.ToLower(opts.heatmap),
+			OutFileName:    opts.out,
+			Suggest:        opts.command == suggestCommand,
+		})
 	}
 
 	if err != nil {
@@ -68,19 +139,52 @@ func main() {
 // Init reads the configuration from environment variables and the flags
 func (c *config) Init() *config {
 	flag.StringVar(&c.sourceFileName, "source", getEnv("source", c.sourceFileName), "source or source matrix file (required)")
-	flag.StringVar(&c.targetFileName, "target", getEnv("target", c.targetFileName), "target or target matrix file (required)")
+	c.targets = newListFlag(getEnv("target", c.targets.String()))
+	flag.Var(c.targets, "target", "target or target matrix file, repeat it to search multiple targets (required)")
 	flag.StringVar(&c.zero, "off", getEnv("off", c.zero), "matrix character that represents a zero or off bit")
 	flag.StringVar(&c.one, "on", getEnv("on", c.one), "matrix character that represents a one or on bit")
+	flag.StringVar(&c.ignore, "ignore", getEnv("ignore", c.ignore), "matrix character that represents an ignored or don't-care cell, empty to disable it")
+	flag.StringVar(&c.alphabet, "alphabet", getEnv("alphabet", c.alphabet), "matrix characters of every level of the cells, from the lowest, i.e. ' .:-=+*#%@'. If set the 'on' and 'off' characters are not used")
+	flag.StringVar(&c.comparison, "comparison", getEnv("comparison", c.comparison), "comparison of the cells of an alphabet or values. Available comparisons are 'exact', 'distance' and 'tolerance'")
+	flag.BoolVar(&c.values, "values", getEnvBool("values", c.values), "the matrixes are numeric values, i.e. grayscale intensities, separated by spaces or commas")
+	flag.Float64Var(&c.tolerance, "tolerance", getEnvFloat("tolerance", c.tolerance), "maximum difference of two equal cells, used by the 'tolerance' comparison")
+	flag.StringVar(&c.threshold, "threshold", getEnv("threshold", c.threshold), "luminance threshold of the PNG or GIF images, the darker pixels are the ones. Available thresholds are 'otsu', 'gray' for a matrix of values, or a luminance from 1 to 255")
 	flag.Float64Var(&c.percentage, "p", getEnvFloat("percentage", c.percentage), "matching percentage")
 	flag.IntVar(&c.delta, "d", getEnvInt("delta", c.delta), "matches blurry delta, the higher it is the less blurry patterns will find")
-	flag.StringVar(&c.output, "o", getEnv("output", c.output), "output format. Availabe formats are 'text' and 'json'")
+	flag.StringVar(&c.strategy, "strategy", getEnv("strategy", c.strategy), "search strategy. Available strategies are '"+strings.Join(finder2d.Searchers(), "', '")+"'")
+	flag.IntVar(&c.workers, "w", getEnvInt("workers", c.workers), "number of workers searching in parallel")
+	flag.BoolVar(&c.orientations, "orientations", getEnvBool("orientations", c.orientations), "search the target in the eight orientations, rotated and flipped")
+	flag.StringVar(&c.scales, "scales", getEnv("scales", c.scales), "comma separated list of scale factors of the target to search")
+	flag.StringVar(&c.metric, "metric", getEnv("metric", c.metric), "scoring metric. Available metrics are 'agreement', 'recall', 'jaccard', 'f1' and 'ncc'")
+	flag.StringVar(&c.weights, "weights", getEnv("weights", c.weights), "file with the weight of every target cell")
+	flag.StringVar(&c.reduction, "reduction", getEnv("reduction", c.reduction), "method to reduce the matches of the same image. Available reductions are 'delta' and 'nms'")
+	flag.Float64Var(&c.iou, "iou", getEnvFloat("iou", c.iou), "maximum intersection over union of two matches to be different images, used by the 'nms' reduction")
+	flag.IntVar(&c.top, "top", getEnvInt("top", c.top), "if set, print only the best N matches sorted by percentage. If no match clears the percentage the best candidate is printed")
+	flag.BoolVar(&c.significance, "significance", getEnvBool("significance", c.significance), "compute the p-value of every match, the probability of a random source with the same density of ones to match at least as well")
+	flag.Float64Var(&c.maxPValue, "maxpvalue", getEnvFloat("maxpvalue", c.maxPValue), "if set, compute the p-value of every match and print only the matches with a p-value not higher than it, i.e. '1e-10'")
+	flag.BoolVar(&c.border, "border", getEnvBool("border", c.border), "also find the partial matches overhanging the borders of the source, comparing only the part of the target inside the source")
+	flag.Float64Var(&c.minVisible, "minvisible", getEnvFloat("minvisible", c.minVisible), "minimum fraction of the target area inside the source of the partial matches, used by 'border'")
+	flag.BoolVar(&c.wrap, "wrap", getEnvBool("wrap", c.wrap), "search the source as a torus, the target crossing the right or bottom border continues at the left or top border, i.e. in tiled textures")
+	flag.StringVar(&c.heatmap, "heatmap", getEnv("heatmap", c.heatmap), "if set, instead of the matches output the score of the target at every position of the source. Available formats are '"+strings.Join(finder2d.HeatmapFormats, "', '")+"'")
+	flag.BoolVar(&c.progress, "progress", getEnvBool("progress", c.progress), "show the search progress")
+	flag.StringVar(&c.output, "o", getEnv("output", c.output), "output format. Availabe formats are '"+strings.Join(finder2d.Formats(), "', '")+"'")
+	flag.StringVar(&c.columns, "columns", getEnv("columns", c.columns), "comma separated list of the matches fields of the 'csv', 'tsv', 'yaml' and 'ndjson' formats. Available columns are 'x', 'y', 'percentage', 'width', 'height', 'orientation', 'scale', 'target', 'pvalue' and 'visible'")
+	flag.StringVar(&c.out, "out", getEnv("out", c.out), "file to write the output, i.e. the 'png' or 'svg' overlay image or the 'html' report of the matches. By default the output is printed")
 	flag.StringVar(&c.port, "port", getEnv("port", c.port), "port to start the server")
 
 	return c
 }
 
+// Read parses the flags and the command, if any, before or after the flags
 func (c *config) Read() {
-	flag.Parse()
+	args := os.Args[1:]
+	if len(args) != 0 && args[0] == suggestCommand {
+		c.command, args = args[0], args[1:]
+	}
+	flag.CommandLine.Parse(args)
+	if len(c.command) == 0 {
+		c.command = flag.Arg(0)
+	}
 }
 
 func getEnv(name string, defValue string) string {
@@ -115,3 +219,48 @@ func getEnvInt(name string, defVal int) int {
 	}
 	return value
 }
+
+func getEnvBool(name string, defVal bool) bool {
+	valStr := getEnv(name, "")
+	if len(valStr) == 0 {
+		return defVal
+	}
+	value, err := strconv.ParseBool(valStr)
+	if err != nil {
+		return defVal
+	}
+	return value
+}
+
+// listFlag is a flag that can be repeated to set a list of values. The default
+// values are a comma separated list, replaced by the first value in the flags
+type listFlag struct {
+	values []string
+	set    bool
+}
+
+func newListFlag(defValue string) *listFlag {
+	l := &listFlag{}
+	for _, v := range strings.Split(defValue, ",") {
+		if v = strings.TrimSpace(v); len(v) != 0 {
+			l.values = append(l.values, v)
+		}
+	}
+	return l
+}
+
+func (l *listFlag) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(l.values, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	if !l.set {
+		l.values = nil
+		l.set = true
+	}
+	l.values = append(l.values, value)
+	return nil
+}
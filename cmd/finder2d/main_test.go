@@ -28,6 +28,7 @@ func testSetup() {
 	os.Setenv(envPrefix+"_CITY", "San Diego")
 	os.Setenv(envPrefix+"_AGE", "42")
 	os.Setenv(envPrefix+"_SCORE", "4.5")
+	os.Setenv(envPrefix+"_ENABLED", "true")
 }
 
 func Test_getEnv(t *testing.T) {
@@ -104,6 +105,30 @@ func Test_getEnvInt(t *testing.T) {
 	}
 }
 
+func Test_getEnvBool(t *testing.T) {
+	testSetup()
+	type args struct {
+		name   string
+		defVal bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"bool", args{"ENABLED", false}, true},
+		{"default value", args{"DISABLED", true}, true},
+		{"not a bool", args{"NAME", false}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getEnvBool(tt.args.name, tt.args.defVal); got != tt.want {
+				t.Errorf("getEnvBool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_config_Read(t *testing.T) {
 	type fields struct {
 		sourceFileName string
@@ -138,7 +163,7 @@ func Test_config_Read(t *testing.T) {
 			
 			c := &config{
 				sourceFileName: tt.fields.sourceFileName,
-				targetFileName: tt.fields.targetFileName,
+				targets:        newListFlag(tt.fields.targetFileName),
 				zero:           tt.fields.zero,
 				one:            tt.fields.one,
 				percentage:     tt.fields.percentage,
@@ -155,7 +180,7 @@ func Test_config_Read(t *testing.T) {
 			c.Read()
 			wantC := &config{
 				sourceFileName: tt.want.sourceFileName,
-				targetFileName: tt.want.targetFileName,
+				targets:        newListFlag(tt.want.targetFileName),
 				zero:           tt.want.zero,
 				one:            tt.want.one,
 				percentage:     tt.want.percentage,
@@ -169,3 +194,29 @@ func Test_config_Read(t *testing.T) {
 		})
 	}
 }
+
+func Test_listFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		defValue string
+		values   []string
+		want     []string
+	}{
+		{"empty", "", nil, nil},
+		{"default value", "cat.txt, dog.txt", nil, []string{"cat.txt", "dog.txt"}},
+		{"replace default value", "cat.txt", []string{"dog.txt", "bird.txt"}, []string{"dog.txt", "bird.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newListFlag(tt.defValue)
+			for _, v := range tt.values {
+				if err := l.Set(v); err != nil {
+					t.Fatalf("listFlag.Set() error = %v", err)
+				}
+			}
+			if !reflect.DeepEqual(l.values, tt.want) {
+				t.Errorf("listFlag values = %v, want %v", l.values, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultFormat is the name of the output format used when none is given
+const DefaultFormat = "text"
+
+// Encoder is implemented by every output format. Encode writes the result of
+// the search of the finder, i.e. the list of matches or an image of them
+type Encoder interface {
+	Encode(w io.Writer, f *Finder2D) error
+}
+
+// EncoderFunc is an adapter to use ordinary functions as an Encoder
+type EncoderFunc func(w io.Writer, f *Finder2D) error
+
+// Encode calls fn(w, f)
+func (fn EncoderFunc) Encode(w io.Writer, f *Finder2D) error {
+	return fn(w, f)
+}
+
+// ColumnsEncoder is implemented by the output formats of the fields of the
+// matches. WithColumns returns a copy of the encoder that writes the given
+// columns, in the given order
+type ColumnsEncoder interface {
+	Encoder
+	WithColumns(columns []Column) Encoder
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]Encoder{}
+)
+
+func init() {
+	text := EncoderFunc(func(w io.Writer, f *Finder2D) error {
+		_, err := io.WriteString(w, f.Matrix())
+		return err
+	})
+	RegisterEncoder("text", text)
+	RegisterEncoder("matrix", text)
+	RegisterEncoder("json", EncoderFunc(func(w io.Writer, f *Finder2D) error {
+		_, err := io.WriteString(w, f.String())
+		return err
+	}))
+	RegisterEncoder("png", EncoderFunc(func(w io.Writer, f *Finder2D) error { return f.EncodeOverlayPNG(w) }))
+	RegisterEncoder("svg", EncoderFunc(func(w io.Writer, f *Finder2D) error { return f.EncodeOverlaySVG(w) }))
+	RegisterEncoder("html", EncoderFunc(func(w io.Writer, f *Finder2D) error { return f.EncodeReport(w) }))
+	RegisterEncoder("pbm", EncoderFunc(func(w io.Writer, f *Finder2D) error { return f.EncodeMatchesPBM(w) }))
+	RegisterEncoder("pgm", EncoderFunc(func(w io.Writer, f *Finder2D) error { return f.EncodeMatchesPGM(w) }))
+	RegisterEncoder("csv", &CSVEncoder{Comma: ','})
+	RegisterEncoder("tsv", &CSVEncoder{Comma: '\t'})
+	RegisterEncoder("yaml", &YAMLEncoder{})
+	RegisterEncoder("ndjson", &NDJSONEncoder{})
+}
+
+// RegisterEncoder makes an output format available by the given name. If
+// RegisterEncoder is called twice with the same name or if the encoder is nil,
+// it panics
+func RegisterEncoder(name string, e Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	if e == nil {
+		panic("finder2d: RegisterEncoder encoder is nil")
+	}
+	if _, dup := encoders[name]; dup {
+		panic("finder2d: RegisterEncoder called twice for encoder " + name)
+	}
+	encoders[name] = e
+}
+
+// GetEncoder returns the output format registered with the given name. An
+// empty name returns the default format
+func GetEncoder(name string) (Encoder, error) {
+	if len(name) == 0 {
+		name = DefaultFormat
+	}
+
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	e, ok := encoders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q. Available formats are: %s", name, strings.Join(encoderNames(), ", "))
+	}
+	return e, nil
+}
+
+// Formats returns the sorted list of the registered output formats names
+func Formats() []string {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	return encoderNames()
+}
+
+func encoderNames() []string {
+	names := make([]string, 0, len(encoders))
+	for name := range encoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Encode writes the result of the search in the given output format, see
+// GetEncoder
+func (f *Finder2D) Encode(w io.Writer, format string) error {
+	e, err := GetEncoder(format)
+	if err != nil {
+		return err
+	}
+	return e.Encode(w, f)
+}
+
+// Column is a field of the matches in the output formats by columns
+type Column string
+
+// The columns of the matches fields
+const (
+	ColumnX           Column = "x"
+	ColumnY           Column = "y"
+	ColumnPercentage  Column = "percentage"
+	ColumnWidth       Column = "width"
+	ColumnHeight      Column = "height"
+	ColumnOrientation Column = "orientation"
+	ColumnScale       Column = "scale"
+	ColumnTarget      Column = "target"
+	ColumnPValue      Column = "pvalue"
+	ColumnVisible     Column = "visible"
+)
+
+// Columns is the list of all the columns
+var Columns = []Column{ColumnX, ColumnY, ColumnPercentage, ColumnWidth, ColumnHeight, ColumnOrientation, ColumnScale, ColumnTarget, ColumnPValue, ColumnVisible}
+
+// ParseColumns returns the columns of a comma separated list of names. An
+// empty list returns no columns, the default columns of the encoders
+func ParseColumns(names string) ([]Column, error) {
+	if len(strings.TrimSpace(names)) == 0 {
+		return nil, nil
+	}
+	var columns []Column
+	for _, name := range strings.Split(names, ",") {
+		c := Column(strings.ToLower(strings.TrimSpace(name)))
+		found := false
+		for _, col := range Columns {
+			found = found || c == col
+		}
+		if !found {
+			all := make([]string, len(Columns))
+			for i, col := range Columns {
+				all[i] = string(col)
+			}
+			return nil, fmt.Errorf("unknown column %q. Available columns are: %s", name, strings.Join(all, ", "))
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+// value returns the value of the column of the match, a number or a string
+func (c Column) value(f *Finder2D, m Match) interface{} {
+	switch c {
+	case ColumnX:
+		return m.X
+	case ColumnY:
+		return m.Y
+	case ColumnPercentage:
+		return m.Percentage
+	case ColumnWidth:
+		w, _ := f.MatchSize(m)
+		return w
+	case ColumnHeight:
+		_, h := f.MatchSize(m)
+		return h
+	case ColumnOrientation:
+		return m.Orientation.String()
+	case ColumnScale:
+		return m.Scale
+	case ColumnTarget:
+		return m.Target
+	case ColumnPValue:
+		return m.PValue
+	case ColumnVisible:
+		return m.Visible
+	}
+	return nil
+}
+
+// text returns the value of the column of the match as text
+func (c Column) text(f *Finder2D, m Match) string {
+	switch v := c.value(f, m).(type) {
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		if c == ColumnPValue {
+			// the p-values are tiny, in scientific notation
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	}
+	return ""
+}
+
+// matchColumns returns the given columns or, if not set, the position,
+// percentage and size of the matches, and their orientation, scale, target,
+// p-value and visible fraction if any match has them
+func (f *Finder2D) matchColumns(columns []Column) []Column {
+	if len(columns) != 0 {
+		return columns
+	}
+	columns = []Column{ColumnX, ColumnY, ColumnPercentage, ColumnWidth, ColumnHeight}
+	var orientation, scale, target, pValue, visible bool
+	for _, m := range f.Matches {
+		orientation = orientation || m.Orientation != Rotate0
+		scale = scale || m.Scale != 0
+		target = target || len(m.Target) != 0
+		pValue = pValue || m.PValue != 0
+		visible = visible || m.Visible != 0
+	}
+	if orientation {
+		columns = append(columns, ColumnOrientation)
+	}
+	if scale {
+		columns = append(columns, ColumnScale)
+	}
+	if target {
+		columns = append(columns, ColumnTarget)
+	}
+	if pValue {
+		columns = append(columns, ColumnPValue)
+	}
+	if visible {
+		columns = append(columns, ColumnVisible)
+	}
+	return columns
+}
+
+// CSVEncoder writes the matches as CSV, with a header row of the columns names
+// and a row per match. The default columns are the position, percentage and
+// size of the matches, and their orientation, scale, target, p-value and
+// visible fraction if any match has them
+type CSVEncoder struct {
+	// Comma is the field delimiter, i.e. `\t` for TSV. The default is `,`
+	Comma   rune
+	Columns []Column
+}
+
+// WithColumns implements the ColumnsEncoder interface
+func (e *CSVEncoder) WithColumns(columns []Column) Encoder {
+	return &CSVEncoder{Comma: e.Comma, Columns: columns}
+}
+
+// Encode implements the Encoder interface
+func (e *CSVEncoder) Encode(w io.Writer, f *Finder2D) error {
+	columns := f.matchColumns(e.Columns)
+	cw := csv.NewWriter(w)
+	if e.Comma != 0 {
+		cw.Comma = e.Comma
+	}
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = string(c)
+	}
+	cw.Write(record)
+	for _, m := range f.Matches {
+		for i, c := range columns {
+			record[i] = c.text(f, m)
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// YAMLEncoder writes the matches as a YAML sequence of mappings of the columns.
+// The default columns are the same of CSVEncoder
+type YAMLEncoder struct {
+	Columns []Column
+}
+
+// WithColumns implements the ColumnsEncoder interface
+func (e *YAMLEncoder) WithColumns(columns []Column) Encoder {
+	return &YAMLEncoder{Columns: columns}
+}
+
+// Encode implements the Encoder interface
+func (e *YAMLEncoder) Encode(w io.Writer, f *Finder2D) error {
+	columns := f.matchColumns(e.Columns)
+	bw := bufio.NewWriter(w)
+	if len(f.Matches) == 0 {
+		bw.WriteString("[]\n")
+	}
+	for _, m := range f.Matches {
+		for i, c := range columns {
+			prefix := "  "
+			if i == 0 {
+				prefix = "- "
+			}
+			v := c.text(f, m)
+			if _, ok := c.value(f, m).(string); ok {
+				// the escape sequences of a Go quoted string are valid in a YAML
+				// double quoted scalar
+				v = strconv.Quote(v)
+			}
+			fmt.Fprintf(bw, "%s%s: %s\n", prefix, c, v)
+		}
+	}
+	return bw.Flush()
+}
+
+// NDJSONEncoder writes the matches as newline delimited JSON, a JSON object of
+// the columns per line. The default columns are the same of CSVEncoder
+type NDJSONEncoder struct {
+	Columns []Column
+}
+
+// WithColumns implements the ColumnsEncoder interface
+func (e *NDJSONEncoder) WithColumns(columns []Column) Encoder {
+	return &NDJSONEncoder{Columns: columns}
+}
+
+// Encode implements the Encoder interface
+func (e *NDJSONEncoder) Encode(w io.Writer, f *Finder2D) error {
+	columns := f.matchColumns(e.Columns)
+	bw := bufio.NewWriter(w)
+	for _, m := range f.Matches {
+		bw.WriteByte('{')
+		for i, c := range columns {
+			if i != 0 {
+				bw.WriteByte(',')
+			}
+			v, err := json.Marshal(c.value(f, m))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(bw, "%q:%s", c, v)
+		}
+		bw.WriteString("}\n")
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,138 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestGetEncoder(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"", false},
+		{"text", false},
+		{"json", false},
+		{"csv", false},
+		{"tsv", false},
+		{"yaml", false},
+		{"ndjson", false},
+		{"xml", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GetEncoder(tt.name); (err != nil) != tt.wantErr {
+				t.Errorf("GetEncoder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// a custom format is available by its name
+	if _, err := GetEncoder("count"); err != nil {
+		RegisterEncoder("count", EncoderFunc(func(w io.Writer, f *Finder2D) error {
+			_, err := io.WriteString(w, "3")
+			return err
+		}))
+	}
+	if got := New(DefaultOne, DefaultZero, 50, 1).Stringf("count"); got != "3" {
+		t.Errorf("Finder2D.Stringf(count) = %q, want %q", got, "3")
+	}
+	if got := New(DefaultOne, DefaultZero, 50, 1).Stringf("xml"); got != "" {
+		t.Errorf("Finder2D.Stringf(xml) = %q, want an empty string", got)
+	}
+	if err := New(DefaultOne, DefaultZero, 50, 1).Encode(&bytes.Buffer{}, "xml"); err == nil {
+		t.Errorf("Finder2D.Encode(xml) expected an error")
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		names   string
+		want    []Column
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"x,y", []Column{ColumnX, ColumnY}, false},
+		{"Target, percentage", []Column{ColumnTarget, ColumnPercentage}, false},
+		{"x,size", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.names, func(t *testing.T) {
+			got, err := ParseColumns(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseColumns() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ParseColumns() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncoders(t *testing.T) {
+	matches := []Match{
+		{X: 1, Y: 2, Percentage: 87.5, Width: 3, Height: 4},
+		{X: 5, Y: 6, Percentage: 100, Width: 4, Height: 3, Orientation: Rotate90, Target: "cat"},
+	}
+	tests := []struct {
+		name    string
+		format  string
+		columns []Column
+		matches []Match
+		want    string
+	}{
+		{"csv", "csv", nil, matches[:1], "x,y,percentage,width,height\n1,2,87.5,3,4\n"},
+		{"csv with optional columns", "csv", nil, matches, "x,y,percentage,width,height,orientation,target\n1,2,87.5,3,4,r0,\n5,6,100,4,3,r90,cat\n"},
+		{"csv with columns", "csv", []Column{ColumnTarget, ColumnX}, matches, "target,x\n,1\ncat,5\n"},
+		{"csv without matches", "csv", nil, nil, "x,y,percentage,width,height\n"},
+		{"tsv", "tsv", nil, matches[:1], "x\ty\tpercentage\twidth\theight\n1\t2\t87.5\t3\t4\n"},
+		{"yaml", "yaml", []Column{ColumnX, ColumnPercentage, ColumnTarget}, matches, "- x: 1\n  percentage: 87.5\n  target: \"\"\n- x: 5\n  percentage: 100\n  target: \"cat\"\n"},
+		{"yaml without matches", "yaml", nil, nil, "[]\n"},
+		{"ndjson", "ndjson", nil, matches, "{\"x\":1,\"y\":2,\"percentage\":87.5,\"width\":3,\"height\":4,\"orientation\":\"r0\",\"target\":\"\"}\n{\"x\":5,\"y\":6,\"percentage\":100,\"width\":4,\"height\":3,\"orientation\":\"r90\",\"target\":\"cat\"}\n"},
+		{"ndjson with columns", "ndjson", []Column{ColumnScale, ColumnY}, matches[:1], "{\"scale\":0,\"y\":2}\n"},
+		{"ndjson without matches", "ndjson", nil, nil, ""},
+		{"csv with p-value", "csv", []Column{ColumnX, ColumnPValue}, []Match{{X: 1, PValue: 1.5e-20}}, "x,pvalue\n1,1.5e-20\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(DefaultOne, DefaultZero, 50, 1)
+			f.Matches = tt.matches
+			e, err := GetEncoder(tt.format)
+			if err != nil {
+				t.Fatalf("GetEncoder() error = %v", err)
+			}
+			if tt.columns != nil {
+				e = e.(ColumnsEncoder).WithColumns(tt.columns)
+			}
+			var b bytes.Buffer
+			if err := e.Encode(&b, f); err != nil {
+				t.Fatalf("Encoder.Encode() error = %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Encoder.Encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -25,9 +25,9 @@ import (
 )
 
 func Example() {
-	var on, off byte
-	on = []byte(`+`)[0]
-	off = []byte(` `)[0]
+	var on, off rune
+	on = '+'
+	off = ' '
 	percentage := 80.0
 	delta := 1
 
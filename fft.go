@@ -0,0 +1,302 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"context"
+	"math"
+	"math/cmplx"
+)
+
+func init() {
+	RegisterSearcher("fft", SearcherFunc(searchFFT))
+}
+
+// grid is a 2D array of complex numbers with a power of 2 width and height,
+// used to calculate the 2D Fast Fourier Transform
+type grid struct {
+	w, h int
+	data []complex128
+}
+
+func newGrid(minW, minH int) *grid {
+	w, h := nextPow2(minW), nextPow2(minH)
+	return &grid{
+		w:    w,
+		h:    h,
+		data: make([]complex128, w*h),
+	}
+}
+
+func nextPow2(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+func (g *grid) set(x, y int, v complex128) {
+	g.data[y*g.w+x] = v
+}
+
+// at returns the value in (x,y)
+func (g *grid) at(x, y int) complex128 {
+	return g.data[y*g.w+x]
+}
+
+// fft2 calculates in place the 2D Fast Fourier Transform or the inverse
+// transform, which is normalized. It stops as soon as the context is done
+// between the rows and the columns, returning the context error
+func (g *grid) fft2(ctx context.Context, inverse bool) error {
+	row := make([]complex128, g.w)
+	rowTw := twiddles(g.w, inverse)
+	for y := 0; y < g.h; y++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		copy(row, g.data[y*g.w:(y+1)*g.w])
+		fft(row, rowTw)
+		copy(g.data[y*g.w:(y+1)*g.w], row)
+	}
+
+	col := make([]complex128, g.h)
+	colTw := twiddles(g.h, inverse)
+	for x := 0; x < g.w; x++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for y := 0; y < g.h; y++ {
+			col[y] = g.data[y*g.w+x]
+		}
+		fft(col, colTw)
+		for y := 0; y < g.h; y++ {
+			g.data[y*g.w+x] = col[y]
+		}
+	}
+
+	if inverse {
+		n := complex(float64(g.w*g.h), 0)
+		for i := range g.data {
+			g.data[i] /= n
+		}
+	}
+	return nil
+}
+
+// correlate returns the cross-correlation of the grid `g` with the grid `g1`,
+// both already transformed and the same size
+func correlate(ctx context.Context, g, g1 *grid) (*grid, error) {
+	c := &grid{
+		w:    g.w,
+		h:    g.h,
+		data: make([]complex128, len(g.data)),
+	}
+	for i := range g.data {
+		c.data[i] = g.data[i] * cmplx.Conj(g1.data[i])
+	}
+	if err := c.fft2(ctx, true); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// twiddles returns the n roots of unity used by the FFT of size n
+func twiddles(n int, inverse bool) []complex128 {
+	sign := -1.0
+	if inverse {
+		sign = 1.0
+	}
+	tw := make([]complex128, n/2)
+	for k := range tw {
+		tw[k] = cmplx.Rect(1, sign*2*math.Pi*float64(k)/float64(n))
+	}
+	return tw
+}
+
+// fft calculates in place the iterative radix-2 Fast Fourier Transform of a,
+// the length of a has to be a power of 2
+func fft(a []complex128, tw []complex128) {
+	n := len(a)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				u := a[start+k]
+				v := a[start+k+half] * tw[k*step]
+				a[start+k] = u + v
+				a[start+k+half] = u - v
+			}
+		}
+	}
+}
+
+// cellValue returns the value of a source cell in the grid to correlate. The
+// ones are real and the zeros imaginary, so the correlation with the target
+// ones or zeros counts both at once. The ignored cells are zero
+func cellValue(v int) complex128 {
+	switch v {
+	case 1:
+		return 1
+	case 0:
+		return 1i
+	}
+	return 0
+}
+
+// transform returns the transformed grid of size (w,h) with the value of the
+// matrix cells
+func transform(ctx context.Context, m *Matrix, w, h int, value func(x, y, v int) complex128) (*grid, error) {
+	g := newGrid(w, h)
+	for y := range m.bits {
+		for x := 0; x < m.maxX; x++ {
+			g.set(x, y, value(x, y, m.Cell(x, y)))
+		}
+	}
+	if err := g.fft2(ctx, false); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// searchFFT calculates the confusion counts at every position with the 2D
+// cross-correlation of the source and the target weighted cells, using FFT.
+// The target ones and zeros are the real and imaginary part of the grid, so a
+// single correlation with the source ones has the TP and FP counts, and the FN
+// and TN counts are the rest of the target ones and zeros. If the source has
+// ignored cells its zeros are also correlated with the target, the ones and
+// zeros of the source are the real and imaginary part of the grid to
+// correlate with the target ones (TP and FN) and zeros (FP and TN). If the
+// target is not weighted the counts are rounded to integers. The matrixes with
+// more than two levels or values are compared cell by cell like the simple
+// strategy
+func searchFFT(ctx context.Context, source, target *Matrix, params SearchParams) ([]Match, error) {
+	matches := []Match{}
+	maxX, maxY := source.Size()
+	width, height := target.Size()
+	if width+height == 0 || width > maxX || height > maxY {
+		return matches, nil
+	}
+	if !source.binary() || !target.binary() {
+		return searchSimple(ctx, source, target, params)
+	}
+
+	count := func(v float64) float64 { return v }
+	if target.weights == nil {
+		count = math.Round
+	}
+
+	var confusion func(x, y int) Confusion
+	if source.care == nil {
+		s, err := transform(ctx, source, maxX, maxY, func(_, _, v int) complex128 {
+			if v == 1 {
+				return 1
+			}
+			return 0
+		})
+		if err != nil {
+			return nil, err
+		}
+		var ones, zeros float64
+		t, err := transform(ctx, target, maxX, maxY, func(x, y, v int) complex128 {
+			w := target.weight(x, y)
+			switch v {
+			case 1:
+				ones += w
+				return complex(w, 0)
+			case 0:
+				zeros += w
+				return complex(0, w)
+			}
+			return 0
+		})
+		if err != nil {
+			return nil, err
+		}
+		c, err := correlate(ctx, s, t)
+		if err != nil {
+			return nil, err
+		}
+		confusion = func(x, y int) Confusion {
+			v := c.at(x, y)
+			tp, fp := count(real(v)), count(-imag(v))
+			return Confusion{TP: tp, TN: zeros - fp, FP: fp, FN: ones - tp}
+		}
+	} else {
+		s, err := transform(ctx, source, maxX, maxY, func(_, _, v int) complex128 {
+			return cellValue(v)
+		})
+		if err != nil {
+			return nil, err
+		}
+		targetCells := func(want int) func(x, y, v int) complex128 {
+			return func(x, y, v int) complex128 {
+				if v != want {
+					return 0
+				}
+				return complex(target.weight(x, y), 0)
+			}
+		}
+		t, err := transform(ctx, target, maxX, maxY, targetCells(1))
+		if err != nil {
+			return nil, err
+		}
+		ones, err := correlate(ctx, s, t)
+		if err != nil {
+			return nil, err
+		}
+		if t, err = transform(ctx, target, maxX, maxY, targetCells(0)); err != nil {
+			return nil, err
+		}
+		zeros, err := correlate(ctx, s, t)
+		if err != nil {
+			return nil, err
+		}
+		confusion = func(x, y int) Confusion {
+			o, z := ones.at(x, y), zeros.at(x, y)
+			return Confusion{TP: count(real(o)), TN: count(imag(z)), FP: count(real(z)), FN: count(imag(o))}
+		}
+	}
+	for y := 0; y+height <= maxY; y++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for x := 0; x+width <= maxX; x++ {
+			p := params.Metric.Score(confusion(x, y))
+			if p >= params.Percentage {
+				matches = append(matches, Match{
+					X:          x,
+					Y:          y,
+					Percentage: p,
+				})
+			}
+		}
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"context"
+	"math/cmplx"
+	"testing"
+)
+
+func TestGrid_fft2(t *testing.T) {
+	g := newGrid(4, 4)
+	g.set(1, 2, 1)
+	if err := g.fft2(context.Background(), false); err != nil {
+		t.Fatalf("grid.fft2() error = %v", err)
+	}
+	if err := g.fft2(context.Background(), true); err != nil {
+		t.Fatalf("grid.fft2() inverse error = %v", err)
+	}
+	for y := 0; y < g.h; y++ {
+		for x := 0; x < g.w; x++ {
+			want := 0.0
+			if x == 1 && y == 2 {
+				want = 1
+			}
+			if v := g.at(x, y); cmplx.Abs(v-complex(want, 0)) > 1e-9 {
+				t.Errorf("grid.fft2() inverse at (%d,%d) = %v, want %v", x, y, v, want)
+			}
+		}
+	}
+
+	// the transform stops when the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := g.fft2(ctx, false); err != context.Canceled {
+		t.Errorf("grid.fft2() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -17,10 +17,14 @@ limitations under the License.
 package finder2d
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"sort"
 )
 
 // DefaultMinMatchPercentage default minimun match percentage. Any match
@@ -34,29 +38,127 @@ const MinDelta = 1
 var (
 	unoMatch  = "\033[47;1m \033[0m" // Bright Blue
 	ceroMatch = "\033[45;1m \033[0m" // Bright Black
+	nuloMatch = "\033[105m \033[0m"  // Bright Magenta
 )
 
 // Default values for a one and a zero in a matrix
 var (
-	DefaultOne  = []byte(`+`)[0]
-	DefaultZero = []byte(` `)[0]
+	DefaultOne  = '+'
+	DefaultZero = ' '
 )
 
 // Match represents the coordinate the target matrix was found in the source
-// matrix and the percentage match
+// matrix and the percentage match. The width and height are the size of the
+// matched area only if it's not the target size in the matched orientation,
+// like a scaled match, see MatchSize. The target is the name of the matched target of the library, if any. The
+// p-value is the significance of the match, if computed, see `Significance`.
+// The visible fraction is the part of the target inside the source, with
+// `Border` the coordinates of a match overhanging the left or top border are
+// negative
 type Match struct {
-	X, Y       int
-	Percentage float64
+	X, Y        int
+	Percentage  float64
+	Width       int         `json:",omitempty"`
+	Height      int         `json:",omitempty"`
+	Orientation Orientation `json:",omitempty"`
+	Scale       float64     `json:",omitempty"`
+	Target      string      `json:",omitempty"`
+	PValue      float64     `json:",omitempty"`
+	Visible     float64     `json:",omitempty"`
 }
 
 // Finder2D is the struct used to find a 2D pattern into a 2D source matrix
 type Finder2D struct {
 	Target     *Matrix
 	Source     *Matrix
-	one, zero  byte
+	one, zero  rune
+	ignore     rune
+	alphabet   string
+	values     bool
 	Matches    []Match
 	Percentage float64
 	Delta      int
+	Strategy   string
+	Workers    int
+	// AnyOrientation, if true, searches the target in the eight orientations,
+	// rotated and flipped
+	AnyOrientation bool
+	// Scales, if set, are the scale factors of the target to search
+	Scales []float64
+	// Metric is the scoring metric of the matches, the default is `Agreement`
+	Metric Metric
+	// Comparison is how the cells are compared if the matrixes have an
+	// alphabet with more than two symbols, the default is `CompareExact`
+	Comparison Comparison
+	// Tolerance is the maximum difference of two equal cells, used by the
+	// `CompareTolerance` comparison
+	Tolerance float64
+	// Reduction is the method to reduce the matches of the same image, the
+	// default is `ReduceDelta` which uses `Delta`, `ReduceNMS` uses
+	// `IoUThreshold`
+	Reduction    Reduction
+	IoUThreshold float64
+	// TopK, if set, keeps only the best K matches sorted by percentage, from
+	// the highest, and position. If no match clears `Percentage` the best
+	// candidate is the only match. The matches with a p-value higher than
+	// `MaxPValue` are removed before ranking them
+	TopK int
+	// Threshold is how the loaded PNG or GIF images are converted into
+	// matrixes, the default is `ThresholdOtsu`
+	Threshold Threshold
+	// Significance, if true, computes the p-value of every match, the
+	// probability of a random source with the same density of ones to match at
+	// least as well. MaxPValue, if set, computes them and keeps only the matches
+	// with a p-value not higher than it
+	Significance bool
+	MaxPValue    float64
+	// Border, if true, also finds the partial matches overhanging the borders
+	// of the source, comparing only the part of the target inside the source,
+	// if it's at least `MinVisible`, a fraction of the target area
+	Border     bool
+	MinVisible float64
+	// Wrap, if true, searches the source as a torus, the targets crossing the
+	// right or bottom border continue at the left or top border, like in a
+	// tiled texture. It can't be used with `Border`
+	Wrap bool
+	// targets is the library of targets searched with the target in `Target`
+	targets map[string]*Matrix
+}
+
+// Option is a function to set optional values of the Finder2D when it's created
+type Option func(*Finder2D)
+
+// WithIgnore sets the value for an ignored cell in the loaded matrixes, the
+// default value is `DefaultIgnore`. A `0` disables the ignored cells
+func WithIgnore(ignore rune) Option {
+	return func(f *Finder2D) {
+		f.ignore = ignore
+	}
+}
+
+// WithAlphabet sets the alphabet of the loaded matrixes, every symbol is a
+// level of the cells from `0`. If it's set the `one` and `zero` values are not
+// used, an invalid alphabet fails when a matrix is loaded
+func WithAlphabet(alphabet string) Option {
+	return func(f *Finder2D) {
+		f.alphabet = alphabet
+	}
+}
+
+// WithValues loads the matrixes as numeric values, i.e. the intensity of the
+// pixels of a grayscale image, see LoadValues. If it's set the alphabet and
+// the `one` and `zero` values are not used
+func WithValues() Option {
+	return func(f *Finder2D) {
+		f.values = true
+	}
+}
+
+// WithWorkers sets the number of workers searching in parallel
+func WithWorkers(n int) Option {
+	return func(f *Finder2D) {
+		f.Workers = n
+	}
 }
 
 func (m *Match) String() string {
@@ -64,7 +166,7 @@ func (m *Match) String() string {
 }
 
 // New create an empty Finder 2D
-func New(one, zero byte, percentage float64, delta int) *Finder2D {
+func New(one, zero rune, percentage float64, delta int, options ...Option) *Finder2D {
 	if percentage == 0 {
 		percentage = DefaultMinMatchPercentage
 	}
@@ -76,12 +178,21 @@ func New(one, zero byte, percentage float64, delta int) *Finder2D {
 		one = DefaultOne
 		zero = DefaultZero
 	}
-	return &Finder2D{
-		one:        one,
-		zero:       zero,
-		Percentage: percentage,
-		Delta:      delta,
+	f := &Finder2D{
+		one:          one,
+		zero:         zero,
+		ignore:       DefaultIgnore,
+		Percentage:   percentage,
+		Delta:        delta,
+		Strategy:     DefaultStrategy,
+		Workers:      DefaultWorkers,
+		IoUThreshold: DefaultIoUThreshold,
+		MinVisible:   DefaultMinVisible,
 	}
+	for _, opt := range options {
+		opt(f)
+	}
+	return f
 }
 
 // String returns the found matches in JSON format
@@ -90,21 +201,84 @@ func (f *Finder2D) String() string {
 	return string(output)
 }
 
-// Stringf return the matches in the requested format
+// Stringf return the matches in the requested output format, see Encode. An
+// unknown format, or a format failing to encode the matches, returns an empty
+// string, use Encode to have the error
 func (f *Finder2D) Stringf(format string) string {
-	switch format {
-	case "", "text", "matrix":
-		return f.Matrix()
-	default: // json
-		return f.String()
+	var b bytes.Buffer
+	if err := f.Encode(&b, format); err != nil {
+		return ""
 	}
+	return b.String()
 }
 
 // Values returns the values for the off and on bits
-func (f *Finder2D) Values() (byte, byte) {
+func (f *Finder2D) Values() (rune, rune) {
 	return f.zero, f.one
 }
 
+// Ignore returns the value for the ignored cells, `0` if they are disabled
+func (f *Finder2D) Ignore() rune {
+	return f.ignore
+}
+
+// Alphabet returns the alphabet of the loaded matrixes, empty if they are
+// loaded with the `one` and `zero` values
+func (f *Finder2D) Alphabet() string {
+	return f.alphabet
+}
+
+// SetAlphabet sets the alphabet of the matrixes to load, see WithAlphabet. An
+// empty alphabet loads the matrixes with the `one` and `zero` values
+func (f *Finder2D) SetAlphabet(alphabet string) error {
+	if len(alphabet) != 0 {
+		if err := checkAlphabet(alphabet, f.ignore); err != nil {
+			return err
+		}
+	}
+	f.alphabet = alphabet
+	return nil
+}
+
+// Sprintf returns the given matrix using the finder values for the cells. The
+// ignored cells use `DefaultIgnore` if they are disabled
+func (f *Finder2D) Sprintf(m *Matrix) string {
+	ignore := f.ignore
+	if ignore == 0 {
+		ignore = DefaultIgnore
+	}
+	if m.values != nil {
+		return m.SprintfValues(string(ignore))
+	}
+	if len(f.alphabet) != 0 {
+		return m.SprintfWithAlphabet(f.alphabet, string(ignore))
+	}
+	return m.SprintfWithIgnore(string(f.zero), string(f.one), string(ignore))
+}
+
+// loadMatrix loads a matrix from a reader with the finder values for the cells,
+// or decodes it if it's a PBM, PGM, PNG or GIF image
+func (f *Finder2D) loadMatrix(r io.Reader) (*Matrix, error) {
+	br := bufio.NewReader(r)
+	if IsNetpbm(br) {
+		return DecodeNetpbm(br)
+	}
+	if IsImage(br) {
+		return DecodeImage(br, f.Threshold)
+	}
+	r = br
+	if f.values {
+		return LoadMatrixValues(r, f.ignore)
+	}
+	if len(f.alphabet) != 0 {
+		return LoadMatrixWithAlphabet(r, f.alphabet, f.ignore)
+	}
+	if f.ignore == 0 {
+		return LoadMatrix(r, f.one, f.zero)
+	}
+	return LoadMatrixWithIgnore(r, f.one, f.zero, f.ignore)
+}
+
 // IsAMatchPoint returns true if the coordinate is a match coordinate
 func (f *Finder2D) IsAMatchPoint(x, y int) bool {
 	for _, m := range f.Matches {
@@ -116,31 +290,94 @@ func (f *Finder2D) IsAMatchPoint(x, y int) bool {
 	return false
 }
 
-// IsInMatchArea return true if the coordinate is in the area of the match
+// IsInMatchArea return true if the coordinate is in the area of the match. With
+// `Wrap` the area of a match crossing the right or bottom border of the source
+// continues at the left or top border
 func (f *Finder2D) IsInMatchArea(x, y int) bool {
 	for _, m := range f.Matches {
-		if (m.X <= x && x <= m.X+f.Target.maxX) && (m.Y <= y && y <= m.Y+f.Target.maxY) {
+		w, h := f.MatchSize(m)
+		dx, dy := x-m.X, y-m.Y
+		if f.Wrap && f.Source != nil {
+			maxX, maxY := f.Source.Size()
+			dx, dy = mod(dx, maxX), mod(dy, maxY)
+		}
+		if (0 <= dx && dx < w) && (0 <= dy && dy < h) {
 			return true
 		}
 	}
 	return false
 }
 
-// Matrix return the matches in the matrix
+// MatchSize returns the size of the area of the match, if the match doesn't
+// have it it's the size of the matched target in the match orientation
+func (f *Finder2D) MatchSize(m Match) (int, int) {
+	if m.Width+m.Height != 0 {
+		return m.Width, m.Height
+	}
+	return f.targetSize(m)
+}
+
+// targetSize returns the size of the matched target in the match orientation
+func (f *Finder2D) targetSize(m Match) (int, int) {
+	target := f.Target
+	if len(m.Target) != 0 {
+		target = f.targets[m.Target]
+	}
+	if target == nil {
+		return 0, 0
+	}
+	w, h := target.Size()
+	if m.Orientation.Transposed() {
+		return h, w
+	}
+	return w, h
+}
+
+// MatchArea returns the area of the source of the match, or nil if it's out of
+// the source. With `Border` the cells of the area out of the source are ignored
+// and with `Wrap` the area crossing the right or bottom border continues at the
+// left or top border
+func (f *Finder2D) MatchArea(m Match) *Matrix {
+	if f.Source == nil {
+		return nil
+	}
+	w, h := f.MatchSize(m)
+	switch {
+	case w*h == 0:
+		return f.Source.Sample(m.X, m.Y, w, h)
+	case f.Border:
+		return f.Source.pad(w-1, h-1).Sample(m.X+w-1, m.Y+h-1, w, h)
+	case f.Wrap:
+		return f.Source.wrap(w-1, h-1).Sample(m.X, m.Y, w, h)
+	}
+	return f.Source.Sample(m.X, m.Y, w, h)
+}
+
+// Matrix return the matches in the matrix. The cells of a source with more than
+// two levels are shades of gray, or of blue in the match area
 func (f *Finder2D) Matrix() string {
+	last := f.Source.Levels() - 1
 	var b bytes.Buffer
 	for y := 0; y < f.Source.maxY; y++ {
 		for x := 0; x < f.Source.maxX; x++ {
 			o := uno
 			z := cero
+			i := nulo
+			shade, shades := 232, 23 // gray
 			if f.IsInMatchArea(x, y) {
 				o = unoMatch
 				z = ceroMatch
+				i = nuloMatch
+				shade, shades = 16, 5 // blue
 			}
-			switch f.Source.Content[y][x] {
-			case 0:
+			switch v := f.Source.Cell(x, y); {
+			case v == Ignored:
+				b.WriteString(i)
+			case last > 1:
+				fmt.Fprintf(&b, "\033[48;5;%dm \033[0m", shade+v*shades/last)
+			case v == 0:
 				b.WriteString(z)
-			case 1:
+			case v == 1:
 				b.WriteString(o)
 			}
 		}
@@ -150,9 +387,11 @@ func (f *Finder2D) Matrix() string {
 }
 
 // LoadSource loads the source from a reader replacing the cell value given in
-// `one` for `1` and `zero` for `0`
+// `one` for `1`, `zero` for `0` and `ignore` for `Ignored`. A PBM or PGM image,
+// identified by its magic number, is decoded with DecodeNetpbm and a PNG or GIF
+// image with DecodeImage and the finder `Threshold`
 func (f *Finder2D) LoadSource(r io.Reader) error {
-	m, err := LoadMatrix(r, f.one, f.zero)
+	m, err := f.loadMatrix(r)
 	if err != nil {
 		return err
 	}
@@ -162,9 +401,12 @@ func (f *Finder2D) LoadSource(r io.Reader) error {
 }
 
 // LoadTarget loads the target from a reader replacing the cell value given in
-// `one` for `1` and `zero` for `0`
+// `one` for `1`, `zero` for `0` and `ignore` for `Ignored`. The ignored cells
+// of the target are not compared, i.e. to mask out the background. A PBM or PGM
+// image, identified by its magic number, is decoded with DecodeNetpbm and a PNG
+// or GIF image with DecodeImage and the finder `Threshold`
 func (f *Finder2D) LoadTarget(r io.Reader) error {
-	m, err := LoadMatrix(r, f.one, f.zero)
+	m, err := f.loadMatrix(r)
 	if err != nil {
 		return err
 	}
@@ -173,15 +415,64 @@ func (f *Finder2D) LoadTarget(r io.Reader) error {
 	return nil
 }
 
+// LoadWeights loads the weight of every cell of the target from a reader. The
+// weights are a matrix of numbers of the target size, see `LoadWeights`
+func (f *Finder2D) LoadWeights(r io.Reader) error {
+	if f.Target == nil {
+		return fmt.Errorf("not set target matrix")
+	}
+	weights, err := LoadWeights(r)
+	if err != nil {
+		return err
+	}
+	return f.Target.SetWeights(weights)
+}
+
 // SearchSimple find the occurences of the target in the source and the percentage
 // match in the simplest way which is to iterate thru the entire matrix searching
 // for the pattern, storing the match when the match percentage is higher than
 // the required
 func (f *Finder2D) SearchSimple() error {
+	return f.search(context.Background(), SearcherFunc(searchSimple), nil)
+}
+
+// SearchOptions are the optional parameters of a search
+type SearchOptions struct {
+	// Progress, if set, is called with the number of rows of the source scanned
+	// and the total of rows to scan
+	Progress ProgressFunc
+}
+
+// Search find the occurences of the target in the source using the search
+// strategy set in `Strategy`, the default strategy is `SearchSimple`. If
+// `Workers` is greater than 1 the source is split in bands of rows searched in
+// parallel, the matches are the same as the sequential search
+func (f *Finder2D) Search() error {
+	return f.SearchContext(context.Background(), nil)
+}
+
+// SearchContext is like Search but stops as soon as the given context is done,
+// returning the context error. The matches are not modified if the search is
+// cancelled. The options, if not nil, are used to report the search progress
+func (f *Finder2D) SearchContext(ctx context.Context, opts *SearchOptions) error {
+	s, err := GetSearcher(f.Strategy)
+	if err != nil {
+		return err
+	}
+	var progress ProgressFunc
+	if opts != nil {
+		progress = opts.Progress
+	}
+	return f.search(ctx, s, progress)
+}
+
+// Validate returns an error if the source or the target is not set, or if any
+// search parameter is invalid
+func (f *Finder2D) Validate() error {
 	if f.Source == nil {
 		return fmt.Errorf("not set source matrix")
 	}
-	if f.Target == nil {
+	if f.Target == nil && len(f.targets) == 0 {
 		return fmt.Errorf("not set target matrix")
 	}
 	if f.Percentage == 0 {
@@ -190,51 +481,350 @@ func (f *Finder2D) SearchSimple() error {
 	if f.Delta == 0 {
 		return fmt.Errorf("delta cannot be 0")
 	}
-	maxX, maxY := f.Source.Size()
-	width, height := f.Target.Size()
+	if f.Workers < 0 {
+		return fmt.Errorf("workers cannot be negative")
+	}
+	for _, scale := range f.Scales {
+		if !validScale(scale) {
+			return fmt.Errorf("scale factor %v has to be a finite number greater than 0", scale)
+		}
+	}
+	if _, err := f.searchParams(); err != nil {
+		return err
+	}
+	if f.Reduction < 0 || int(f.Reduction) >= len(Reductions) {
+		return fmt.Errorf("unknown reduction %s", f.Reduction)
+	}
+	if f.IoUThreshold < 0 || f.IoUThreshold > 1 {
+		return fmt.Errorf("IoU threshold has to be between 0 and 1")
+	}
+	if f.TopK < 0 {
+		return fmt.Errorf("top K cannot be negative")
+	}
+	if f.MaxPValue < 0 || f.MaxPValue > 1 {
+		return fmt.Errorf("maximum p-value has to be between 0 and 1")
+	}
+	if f.MinVisible < 0 || f.MinVisible > 1 {
+		return fmt.Errorf("minimum visible fraction has to be between 0 and 1")
+	}
+	if f.Border && f.Wrap {
+		return fmt.Errorf("border and wrap modes cannot be used together")
+	}
+	return nil
+}
 
-	for y := 0; y < maxY; y++ {
-		for x := 0; x < maxX; x++ {
-			sample := f.Source.Sample(x, y, width, height)
-			if sample == nil {
-				break
-			}
-			p, err := sample.Compare(f.Target)
+func (f *Finder2D) search(ctx context.Context, s Searcher, progress ProgressFunc) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	params, err := f.searchParams()
+	if err != nil {
+		return err
+	}
+	variants := f.variants()
+
+	matches, err := f.searchVariants(ctx, s, variants, params, progress)
+	if err != nil {
+		return err
+	}
+
+	switch f.Reduction {
+	case ReduceNMS:
+		matches = suppressMatches(matches, f.IoUThreshold, f.torus())
+	default:
+		matches = reduceMatches(matches, f.Delta, f.torus())
+	}
+
+	// the matches with a higher p-value than the maximum are not ranked
+	significance := f.Significance || f.MaxPValue != 0
+	if significance {
+		matches = f.pValues(matches, variants)
+	}
+
+	if f.TopK > 0 && len(matches) == 0 {
+		// the best candidate of every position is the best match of any group
+		params.Percentage = -math.MaxFloat64
+		for _, v := range variants {
+			ms, err := f.searchVariants(ctx, s, []variant{v}, params, nil)
 			if err != nil {
 				return err
 			}
-			if p >= f.Percentage {
-				f.Matches = append(f.Matches, Match{
-					X:          x,
-					Y:          y,
-					Percentage: p,
-				})
+			if significance {
+				ms = f.pValues(ms, []variant{v})
 			}
+			matches = topMatches(append(matches, ms...), 1)
 		}
 	}
+	if f.TopK > 0 {
+		matches = topMatches(matches, f.TopK)
+	}
 
-	f.Matches = reduceMatches(f.Matches, f.Delta)
+	for i, m := range matches {
+		if w, h := f.targetSize(m); m.Width == w && m.Height == h {
+			matches[i].Width, matches[i].Height = 0, 0
+		}
+	}
+	f.Matches = matches
 
 	return nil
 }
 
-func around(m Match, ms []Match, d int) bool {
+// searchParams returns the parameters to compare the target with the source,
+// or an error if any of them is invalid
+func (f *Finder2D) searchParams() (SearchParams, error) {
+	if f.Metric < 0 || int(f.Metric) >= len(Metrics) {
+		return SearchParams{}, fmt.Errorf("unknown metric %s", f.Metric)
+	}
+	if f.Comparison < 0 || int(f.Comparison) >= len(Comparisons) {
+		return SearchParams{}, fmt.Errorf("unknown comparison %s", f.Comparison)
+	}
+	if f.Tolerance < 0 || math.IsNaN(f.Tolerance) {
+		return SearchParams{}, fmt.Errorf("tolerance cannot be negative")
+	}
+	return SearchParams{
+		Percentage: f.Percentage,
+		Metric:     f.Metric,
+		Comparison: f.Comparison,
+		Tolerance:  f.Tolerance,
+	}, nil
+}
+
+// searchVariants returns the matches of every variant in scan order, without
+// reduction. If the searcher searches several targets in a single pass, like
+// the simple strategy, the source is scanned once for all the variants,
+// otherwise it's scanned once for every variant
+func (f *Finder2D) searchVariants(ctx context.Context, s Searcher, variants []variant, params SearchParams, progress ProgressFunc) ([]Match, error) {
+	var matches []Match
+	var err error
+	if ts, ok := s.(targetsSearcher); ok {
+		matches, err = f.searchVariantsOnce(ctx, ts, variants, params, progress)
+	} else {
+		matches, err = f.searchEveryVariant(ctx, s, variants, params, progress)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the matches of every variant are in scan order, merge them in scan order
+	if len(variants) > 1 {
+		sort.SliceStable(matches, func(i, j int) bool {
+			if matches[i].Y != matches[j].Y {
+				return matches[i].Y < matches[j].Y
+			}
+			return matches[i].X < matches[j].X
+		})
+	}
+
+	return matches, nil
+}
+
+// searchVariantsOnce searches all the variants in a single pass of the source,
+// with `Border` or `Wrap` the source is extended for the largest variant
+func (f *Finder2D) searchVariantsOnce(ctx context.Context, s targetsSearcher, variants []variant, params SearchParams, progress ProgressFunc) ([]Match, error) {
+	maxX, maxY := f.Source.Size()
+	fits := func(w, h int) bool {
+		return w+h != 0 && w <= maxX && h <= maxY
+	}
+	var width, height int
+	for _, v := range variants {
+		w, h := v.target.Size()
+		if f.Wrap && !fits(w, h) {
+			continue
+		}
+		if w > width {
+			width = w
+		}
+		if h > height {
+			height = h
+		}
+	}
+	source := f.searchSource(width, height)
+
+	targets := make([]scanTarget, len(variants))
+	for i, v := range variants {
+		switch w, h := v.target.Size(); {
+		case f.Wrap && fits(w, h):
+			// on the torus the target is searched at every position of the source
+			targets[i] = scanTarget{v.target, maxX, maxY}
+		case f.Wrap:
+			targets[i] = scanTarget{target: v.target}
+		default:
+			targets[i] = newScanTarget(source, v.target)
+		}
+	}
+	found, err := searchTargetsBands(ctx, s, source, targets, params, f.Workers, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []Match{}
+	for i, v := range variants {
+		matches = append(matches, f.variantMatches(found[i], v, width-1, height-1)...)
+	}
+	return matches, nil
+}
+
+// searchEveryVariant searches every variant in its own pass of the source with
+// the searcher
+func (f *Finder2D) searchEveryVariant(ctx context.Context, s Searcher, variants []variant, params SearchParams, progress ProgressFunc) ([]Match, error) {
+	var total, scanned int
+	sources := make([]*Matrix, len(variants))
+	for i, v := range variants {
+		sources[i] = f.searchSource(v.target.Size())
+		total += scanRows(sources[i], v.target)
+	}
+
+	matches := []Match{}
+	for i, v := range variants {
+		var p ProgressFunc
+		if progress != nil {
+			offset := scanned
+			p = func(s, _ int) {
+				progress(offset+s, total)
+			}
+		}
+		ms, err := searchBands(ctx, s, sources[i], v.target, params, f.Workers, p)
+		if err != nil {
+			return nil, err
+		}
+		w, h := v.target.Size()
+		matches = append(matches, f.variantMatches(ms, v, w-1, h-1)...)
+		scanned += scanRows(sources[i], v.target)
+	}
+	return matches, nil
+}
+
+// variantMatches sets the size, orientation, scale and target of the matches
+// of the variant. With `Border` the matches are moved from the source padded
+// with `dx` columns and `dy` rows, see borderMatches
+func (f *Finder2D) variantMatches(matches []Match, v variant, dx, dy int) []Match {
+	if f.Border {
+		matches = f.borderMatches(matches, v.target, dx, dy)
+	}
+	w, h := v.target.Size()
+	for i := range matches {
+		matches[i].Width, matches[i].Height = w, h
+		matches[i].Orientation = v.orientation
+		matches[i].Scale = v.scale
+		matches[i].Target = v.name
+	}
+	return matches
+}
+
+// topMatches returns the best k matches sorted by percentage, from the
+// highest, and then by position in scan order
+func topMatches(matches []Match, k int) []Match {
+	sorted := append([]Match{}, matches...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Percentage != sorted[j].Percentage {
+			return sorted[i].Percentage > sorted[j].Percentage
+		}
+		if sorted[i].Y != sorted[j].Y {
+			return sorted[i].Y < sorted[j].Y
+		}
+		return sorted[i].X < sorted[j].X
+	})
+	if len(sorted) > k {
+		sorted = sorted[:k]
+	}
+	return sorted
+}
+
+// variant is a transformation of a target to search in the source
+type variant struct {
+	name        string
+	target      *Matrix
+	orientation Orientation
+	scale       float64
+}
+
+// variants returns the transformations of every target to search, in every
+// scale and orientation. The transformations of a target with the same content
+// as a previous one of the same target are not included
+func (f *Finder2D) variants() []variant {
+	orientations := Orientations[:1]
+	if f.AnyOrientation {
+		orientations = Orientations
+	}
+	scales := f.Scales
+	if len(scales) == 0 {
+		scales = []float64{0}
+	}
+
+	// the scales of the target larger than the source, in any orientation, are
+	// not searched, they can't be found
+	maxX, maxY := f.Source.Size()
+	fits := func(w, h int) bool {
+		return (w <= maxX && h <= maxY) || (f.AnyOrientation && h <= maxX && w <= maxY)
+	}
+
+	variants := []variant{}
+	for _, nt := range f.searchTargets() {
+		first := len(variants)
+		for _, scale := range scales {
+			target := nt.target
+			if scale != 0 {
+				if w, h := nt.target.Size(); !fits(scaleSize(w, h, scale)) {
+					continue
+				}
+				target = nt.target.Scale(scale)
+			}
+			for _, o := range orientations {
+				t := target
+				if o != Rotate0 {
+					t = target.Transform(o)
+				}
+				dup := false
+				for _, v := range variants[first:] {
+					if v.target.equal(t) {
+						dup = true
+						break
+					}
+				}
+				if !dup {
+					variants = append(variants, variant{nt.name, t, o, scale})
+				}
+			}
+		}
+	}
+	return variants
+}
+
+func around(m Match, ms []Match, d int, t torus) bool {
 	for _, m1 := range ms {
-		dx := m1.X - m.X
-		dy := m1.Y - m.Y
-		if (dx >= -d && dx <= d) && (dy >= -d && dy <= d) {
+		if near(m, m1, d, t) {
 			return true
 		}
 	}
 	return false
 }
 
+// near returns true if both matches are considered the same image. Matches of
+// different targets are never the same image. Matches of the same size are
+// near if their coordinates differ in at most `d`, matches of different size
+// (i.e. different scale) are near if their areas overlap. On a torus the
+// coordinates and the areas wrap around its borders
+func near(m, m1 Match, d int, t torus) bool {
+	if m.Target != m1.Target {
+		return false
+	}
+	if m.Width != m1.Width || m.Height != m1.Height {
+		return overlap(m, m1, t) >= MinScaleOverlap
+	}
+	dx := offset(m.X, m1.X, t.w)
+	dy := offset(m.Y, m1.Y, t.h)
+	return (dx >= -d && dx <= d) && (dy >= -d && dy <= d)
+}
+
+// bestMatch returns the match with the higher percentage, on a tie the match
+// with the larger area, which is more evidence of the image than a smaller one.
+// The percentage may be negative, like the NCC score of an inverted image
 func bestMatch(matches []Match) Match {
-	var higherP float64
+	higherP := math.Inf(-1)
 	var bestMatch Match
 
 	for _, m := range matches {
-		if m.Percentage >= higherP {
+		if m.Percentage > higherP || (m.Percentage == higherP && m.Width*m.Height >= bestMatch.Width*bestMatch.Height) {
 			bestMatch = m
 			higherP = m.Percentage
 		}
@@ -243,7 +833,7 @@ func bestMatch(matches []Match) Match {
 	return bestMatch
 }
 
-func groupMatchesNear(m Match, initialUniv []Match, delta int) (group []Match, universe []Match) {
+func groupMatchesNear(m Match, initialUniv []Match, delta int, t torus) (group []Match, universe []Match) {
 	univ := initialUniv
 	var mov int
 	group = []Match{m}
@@ -251,7 +841,7 @@ func groupMatchesNear(m Match, initialUniv []Match, delta int) (group []Match, u
 	for {
 		newUniv := []Match{}
 		for _, mi := range univ {
-			if around(mi, group, delta) {
+			if around(mi, group, delta, t) {
 				group = append(group, mi)
 				mov++
 			} else {
@@ -268,7 +858,7 @@ func groupMatchesNear(m Match, initialUniv []Match, delta int) (group []Match, u
 	return group, univ
 }
 
-func reduceMatches(matches []Match, delta int) []Match {
+func reduceMatches(matches []Match, delta int, t torus) []Match {
 	retMatches := []Match{}
 	if len(matches) == 0 {
 		return retMatches
@@ -280,7 +870,7 @@ func reduceMatches(matches []Match, delta int) []Match {
 		m := matches[0]
 		matches = matches[1:]
 
-		matchGroup, matches = groupMatchesNear(m, matches, delta)
+		matchGroup, matches = groupMatchesNear(m, matches, delta, t)
 
 		bestM := bestMatch(matchGroup)
 		retMatches = append(retMatches, bestM)
@@ -17,15 +17,21 @@ limitations under the License.
 package finder2d
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
 func Test_around(t *testing.T) {
 	ms := []Match{
-		Match{44, 20, 51.555556},
-		Match{45, 21, 57.333333},
-		Match{46, 22, 51.111111},
+		Match{X: 44, Y: 20, Percentage: 51.555556},
+		Match{X: 45, Y: 21, Percentage: 57.333333},
+		Match{X: 46, Y: 22, Percentage: 51.111111},
 	}
 	tests := []struct {
 		name string
@@ -33,20 +39,25 @@ func Test_around(t *testing.T) {
 		ms   []Match
 		d    int
 		want bool
+		t    torus
 	}{
-		{"empty list", Match{1, 2, 3.4}, []Match{}, 1, false},
-		{"near X (1)", Match{43, 21, 0.0}, ms, 1, true},
-		{"near X,Y (1)", Match{43, 19, 0.0}, ms, 1, true},
-		{"near X (2)", Match{47, 23, 0.0}, ms, 1, true},
-		{"near XY (2)", Match{47, 21, 0.0}, ms, 1, true},
-		{"far X (1)", Match{42, 21, 0.0}, ms, 1, false},
-		{"near X, far Y (1)", Match{43, 22, 0.0}, ms, 1, false},
-		{"far X (2)", Match{48, 23, 0.0}, ms, 1, false},
-		{"near X far Y (2)", Match{47, 20, 0.0}, ms, 1, false},
+		{"empty list", Match{X: 1, Y: 2, Percentage: 3.4}, []Match{}, 1, false, torus{}},
+		{"near X (1)", Match{X: 43, Y: 21, Percentage: 0.0}, ms, 1, true, torus{}},
+		{"near X,Y (1)", Match{X: 43, Y: 19, Percentage: 0.0}, ms, 1, true, torus{}},
+		{"near X (2)", Match{X: 47, Y: 23, Percentage: 0.0}, ms, 1, true, torus{}},
+		{"near XY (2)", Match{X: 47, Y: 21, Percentage: 0.0}, ms, 1, true, torus{}},
+		{"far X (1)", Match{X: 42, Y: 21, Percentage: 0.0}, ms, 1, false, torus{}},
+		{"near X, far Y (1)", Match{X: 43, Y: 22, Percentage: 0.0}, ms, 1, false, torus{}},
+		{"far X (2)", Match{X: 48, Y: 23, Percentage: 0.0}, ms, 1, false, torus{}},
+		{"near X far Y (2)", Match{X: 47, Y: 20, Percentage: 0.0}, ms, 1, false, torus{}},
+		{"far across the seam", Match{X: 0, Y: 21, Percentage: 0.0}, ms, 1, false, torus{}},
+		{"near across the seam", Match{X: 0, Y: 21, Percentage: 0.0}, ms, 1, true, torus{47, 30}},
+		{"near across the corner", Match{X: 0, Y: 0, Percentage: 0.0}, []Match{{X: 46, Y: 29}}, 1, true, torus{47, 30}},
+		{"far across the seam wrapped", Match{X: 0, Y: 21, Percentage: 0.0}, ms, 1, false, torus{48, 30}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := around(tt.m, tt.ms, tt.d); got != tt.want {
+			if got := around(tt.m, tt.ms, tt.d, tt.t); got != tt.want {
 				t.Errorf("around() = %v, want %v", got, tt.want)
 			}
 		})
@@ -61,37 +72,42 @@ func Test_bestMatch(t *testing.T) {
 	}{
 		{"empty", []Match{}, Match{}},
 		{"one", []Match{
-			Match{74, 0, 52.888889},
-			Match{75, 0, 54.222222},
-			Match{76, 0, 57.777778},
-			Match{77, 0, 56.444444},
-			Match{78, 0, 55.555556},
-			Match{79, 0, 72.000000},
-			Match{80, 0, 99.111111},
-			Match{81, 0, 71.555556},
-			Match{82, 0, 55.555556},
-			Match{83, 0, 58.222222},
-			Match{84, 0, 60.000000},
-			Match{85, 0, 53.777778},
-			Match{76, 1, 50.222222},
-			Match{77, 1, 51.111111},
-		}, Match{80, 0, 99.111111}},
+			Match{X: 74, Y: 0, Percentage: 52.888889},
+			Match{X: 75, Y: 0, Percentage: 54.222222},
+			Match{X: 76, Y: 0, Percentage: 57.777778},
+			Match{X: 77, Y: 0, Percentage: 56.444444},
+			Match{X: 78, Y: 0, Percentage: 55.555556},
+			Match{X: 79, Y: 0, Percentage: 72.000000},
+			Match{X: 80, Y: 0, Percentage: 99.111111},
+			Match{X: 81, Y: 0, Percentage: 71.555556},
+			Match{X: 82, Y: 0, Percentage: 55.555556},
+			Match{X: 83, Y: 0, Percentage: 58.222222},
+			Match{X: 84, Y: 0, Percentage: 60.000000},
+			Match{X: 85, Y: 0, Percentage: 53.777778},
+			Match{X: 76, Y: 1, Percentage: 50.222222},
+			Match{X: 77, Y: 1, Percentage: 51.111111},
+		}, Match{X: 80, Y: 0, Percentage: 99.111111}},
 		{"multiple", []Match{
-			Match{74, 0, 52.888889},
-			Match{75, 0, 54.222222},
-			Match{76, 0, 99.111111},
-			Match{77, 0, 56.444444},
-			Match{78, 0, 55.555556},
-			Match{79, 0, 72.000000},
-			Match{80, 0, 99.111111},
-			Match{81, 0, 71.555556},
-			Match{82, 0, 55.555556},
-			Match{83, 0, 99.111111},
-			Match{84, 0, 60.000000},
-			Match{85, 0, 53.777778},
-			Match{76, 1, 50.222222},
-			Match{77, 1, 51.111111},
-		}, Match{83, 0, 99.111111}},
+			Match{X: 74, Y: 0, Percentage: 52.888889},
+			Match{X: 75, Y: 0, Percentage: 54.222222},
+			Match{X: 76, Y: 0, Percentage: 99.111111},
+			Match{X: 77, Y: 0, Percentage: 56.444444},
+			Match{X: 78, Y: 0, Percentage: 55.555556},
+			Match{X: 79, Y: 0, Percentage: 72.000000},
+			Match{X: 80, Y: 0, Percentage: 99.111111},
+			Match{X: 81, Y: 0, Percentage: 71.555556},
+			Match{X: 82, Y: 0, Percentage: 55.555556},
+			Match{X: 83, Y: 0, Percentage: 99.111111},
+			Match{X: 84, Y: 0, Percentage: 60.000000},
+			Match{X: 85, Y: 0, Percentage: 53.777778},
+			Match{X: 76, Y: 1, Percentage: 50.222222},
+			Match{X: 77, Y: 1, Percentage: 51.111111},
+		}, Match{X: 83, Y: 0, Percentage: 99.111111}},
+		{"negative", []Match{
+			Match{X: 74, Y: 0, Percentage: -52.888889},
+			Match{X: 75, Y: 0, Percentage: -14.222222},
+			Match{X: 76, Y: 0, Percentage: -99.111111},
+		}, Match{X: 75, Y: 0, Percentage: -14.222222}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -101,3 +117,389 @@ func Test_bestMatch(t *testing.T) {
 		})
 	}
 }
+
+func loadTestFinder(t *testing.T, percentage float64, delta int) *Finder2D {
+	f := New(DefaultOne, DefaultZero, percentage, delta)
+
+	source, err := os.Open("test_data/image_with_cats.txt")
+	if err != nil {
+		t.Fatalf("failed to open the source matrix. %s", err)
+	}
+	defer source.Close()
+	if err := f.LoadSource(source); err != nil {
+		t.Fatalf("failed to load the source matrix. %s", err)
+	}
+
+	target, err := os.Open("test_data/perfect_cat_image.txt")
+	if err != nil {
+		t.Fatalf("failed to open the target matrix. %s", err)
+	}
+	defer target.Close()
+	if err := f.LoadTarget(target); err != nil {
+		t.Fatalf("failed to load the target matrix. %s", err)
+	}
+
+	return f
+}
+
+func TestFinder2D_Search(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage float64
+		delta      int
+	}{
+		{"default", DefaultMinMatchPercentage, MinDelta},
+		{"p=61 d=1", 61.0, 1},
+		{"p=80 d=1", 80.0, 1},
+		{"p=48 d=5", 48.0, 5},
+	}
+	for _, strategy := range Searchers() {
+		for _, tt := range tests {
+			t.Run(strategy+" "+tt.name, func(t *testing.T) {
+				want := loadTestFinder(t, tt.percentage, tt.delta)
+				if err := want.SearchSimple(); err != nil {
+					t.Fatalf("Finder2D.SearchSimple() error = %v", err)
+				}
+
+				f := loadTestFinder(t, tt.percentage, tt.delta)
+				f.Strategy = strategy
+				if err := f.Search(); err != nil {
+					t.Fatalf("Finder2D.Search() error = %v", err)
+				}
+				if !reflect.DeepEqual(f.Matches, want.Matches) {
+					t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want.Matches)
+				}
+			})
+		}
+	}
+}
+
+func TestFinder2D_Search_workers(t *testing.T) {
+	want := loadTestFinder(t, 61.0, 1)
+	if err := want.SearchSimple(); err != nil {
+		t.Fatalf("Finder2D.SearchSimple() error = %v", err)
+	}
+	for _, strategy := range Searchers() {
+		for _, workers := range []int{0, 2, 3, 7, 200, math.MaxInt32} {
+			t.Run(fmt.Sprintf("%s %d workers", strategy, workers), func(t *testing.T) {
+				f := loadTestFinder(t, 61.0, 1)
+				f.Strategy = strategy
+				f.Workers = workers
+				if err := f.Search(); err != nil {
+					t.Fatalf("Finder2D.Search() error = %v", err)
+				}
+				if !reflect.DeepEqual(f.Matches, want.Matches) {
+					t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want.Matches)
+				}
+			})
+		}
+	}
+}
+
+func TestFinder2D_SearchContext(t *testing.T) {
+	want := loadTestFinder(t, 61.0, 1)
+	if err := want.SearchSimple(); err != nil {
+		t.Fatalf("Finder2D.SearchSimple() error = %v", err)
+	}
+	for _, strategy := range Searchers() {
+		for _, workers := range []int{1, 4} {
+			name := fmt.Sprintf("%s %d workers", strategy, workers)
+			t.Run(name+" cancelled", func(t *testing.T) {
+				f := loadTestFinder(t, 61.0, 1)
+				f.Strategy = strategy
+				f.Workers = workers
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				if err := f.SearchContext(ctx, nil); err != context.Canceled {
+					t.Errorf("Finder2D.SearchContext() error = %v, want %v", err, context.Canceled)
+				}
+				if f.Matches != nil {
+					t.Errorf("Finder2D.SearchContext() matches = %v, want none", f.Matches)
+				}
+			})
+			t.Run(name+" progress", func(t *testing.T) {
+				f := loadTestFinder(t, 61.0, 1)
+				f.Strategy = strategy
+				f.Workers = workers
+				var last, total int
+				monotonic := true
+				opts := &SearchOptions{
+					Progress: func(scanned, t int) {
+						monotonic = monotonic && scanned >= last
+						last, total = scanned, t
+					},
+				}
+				if err := f.SearchContext(context.Background(), opts); err != nil {
+					t.Fatalf("Finder2D.SearchContext() error = %v", err)
+				}
+				if !monotonic {
+					t.Errorf("Finder2D.SearchContext() progress is not monotonic")
+				}
+				if last != 86 || total != 86 {
+					t.Errorf("Finder2D.SearchContext() progress = %d/%d, want 86/86", last, total)
+				}
+				if !reflect.DeepEqual(f.Matches, want.Matches) {
+					t.Errorf("Finder2D.SearchContext() = %v, want %v", f.Matches, want.Matches)
+				}
+			})
+		}
+	}
+}
+
+func Test_splitBands(t *testing.T) {
+	tests := []struct {
+		name string
+		rows int
+		n    int
+		want []band
+	}{
+		{"one band", 10, 1, []band{{0, 10}}},
+		{"exact", 10, 2, []band{{0, 5}, {5, 10}}},
+		{"uneven", 10, 3, []band{{0, 3}, {3, 6}, {6, 10}}},
+		{"more bands than rows", 2, 5, []band{{0, 1}, {1, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitBands(tt.rows, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitBands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchers_random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+	randomMatrix := func(w, h int, ignored bool) *Matrix {
+		content := make([][]int, h)
+		for y := range content {
+			content[y] = make([]int, w)
+			for x := range content[y] {
+				content[y][x] = rnd.Intn(2)
+				if ignored && rnd.Intn(4) == 0 {
+					content[y][x] = Ignored
+				}
+			}
+		}
+		m, err := NewMatrix(content)
+		if err != nil {
+			t.Fatalf("failed to create the matrix. %s", err)
+		}
+		return m
+	}
+	randomLevels := func(w, h, levels int, ignored bool) *Matrix {
+		m := randomMatrix(w, h, ignored)
+		for _, row := range m.Content() {
+			for x, v := range row {
+				if v != Ignored {
+					row[x] = rnd.Intn(levels)
+				}
+			}
+		}
+		m.levels = levels
+		return m
+	}
+	randomValues := func(w, h int, ignored bool) *Matrix {
+		values := make([][]float64, h)
+		for y := range values {
+			values[y] = make([]float64, w)
+			for x := range values[y] {
+				values[y][x] = rnd.Float64() * 255
+				if ignored && rnd.Intn(4) == 0 {
+					values[y][x] = math.NaN()
+				}
+			}
+		}
+		m, err := NewValuesMatrix(values)
+		if err != nil {
+			t.Fatalf("failed to create the matrix. %s", err)
+		}
+		return m
+	}
+	randomWeighted := func(m *Matrix) *Matrix {
+		w, h := m.Size()
+		weights := make([][]float64, h)
+		for y := range weights {
+			weights[y] = make([]float64, w)
+			for x := range weights[y] {
+				weights[y][x] = float64(rnd.Intn(4)) * 0.7
+			}
+		}
+		if err := m.SetWeights(weights); err != nil {
+			t.Fatalf("failed to set the weights. %s", err)
+		}
+		return m
+	}
+	tests := []struct {
+		name   string
+		source *Matrix
+		target *Matrix
+	}{
+		{"square", randomMatrix(30, 30, false), randomMatrix(5, 5, false)},
+		{"wide target", randomMatrix(150, 12, false), randomMatrix(70, 4, false)},
+		{"tall target", randomMatrix(17, 40, false), randomMatrix(3, 11, false)},
+		{"same size", randomMatrix(9, 9, false), randomMatrix(9, 9, false)},
+		{"larger target", randomMatrix(5, 5, false), randomMatrix(6, 2, false)},
+		{"ignored in target", randomMatrix(90, 20, false), randomMatrix(66, 5, true)},
+		{"ignored in source", randomMatrix(90, 20, true), randomMatrix(7, 5, false)},
+		{"ignored in both", randomMatrix(40, 30, true), randomMatrix(6, 8, true)},
+		{"all ignored", randomMatrix(10, 10, false), newTestMatrix(t, [][]int{{Ignored, Ignored}, {Ignored, Ignored}})},
+		{"weighted", randomMatrix(80, 20, false), randomWeighted(randomMatrix(66, 5, false))},
+		{"weighted and ignored", randomMatrix(40, 30, true), randomWeighted(randomMatrix(6, 8, true))},
+		{"levels", randomLevels(30, 20, 5, false), randomLevels(4, 3, 5, false)},
+		{"levels and binary", randomLevels(30, 20, 3, true), randomMatrix(4, 3, false)},
+		{"levels weighted and ignored", randomLevels(40, 30, 10, true), randomWeighted(randomLevels(6, 8, 10, true))},
+		{"values", randomValues(30, 20, false), randomValues(4, 3, false)},
+		{"values weighted and ignored", randomValues(40, 30, true), randomWeighted(randomValues(6, 8, true))},
+	}
+	for _, strategy := range Searchers() {
+		s, _ := GetSearcher(strategy)
+		for _, metric := range Metrics {
+			for _, tt := range tests {
+				t.Run(strategy+" "+metric.String()+" "+tt.name, func(t *testing.T) {
+					// a negative percentage returns every position, even with a negative NCC
+					params := SearchParams{Percentage: -100, Metric: metric, Comparison: CompareDistance}
+					want, _ := searchSimple(context.Background(), tt.source, tt.target, params)
+					got, err := s.Search(context.Background(), tt.source, tt.target, params)
+					if err != nil {
+						t.Fatalf("Searcher.Search() error = %v", err)
+					}
+					if tt.target.Weights() == nil {
+						if !reflect.DeepEqual(got, want) {
+							t.Errorf("Searcher.Search() = %v, want %v", got, want)
+						}
+						return
+					}
+					// the weighted scores may differ in the floating point rounding
+					if len(got) != len(want) {
+						t.Fatalf("Searcher.Search() = %d matches, want %d", len(got), len(want))
+					}
+					for i := range got {
+						if got[i].X != want[i].X || got[i].Y != want[i].Y || math.Abs(got[i].Percentage-want[i].Percentage) > 1e-9 {
+							t.Fatalf("Searcher.Search() match #%d = %v, want %v", i, got[i], want[i])
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestGetSearcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"", false},
+		{DefaultStrategy, false},
+		{"unknown", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GetSearcher(tt.name); (err != nil) != tt.wantErr {
+				t.Errorf("GetSearcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Search_ignored(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	content := make([][]int, 20)
+	for y := range content {
+		content[y] = make([]int, 30)
+		for x := range content[y] {
+			content[y][x] = rnd.Intn(2)
+		}
+	}
+	cross := []string{
+		"?? ??",
+		"? + ?",
+		" +++ ",
+		"? + ?",
+		"?? ??",
+	}
+	for y, row := range cross {
+		for x, c := range row {
+			switch c {
+			case '+':
+				content[4+y][7+x] = 1
+			case ' ':
+				content[4+y][7+x] = 0
+			}
+		}
+	}
+	source := newTestMatrix(t, content)
+
+	for _, strategy := range Searchers() {
+		t.Run(strategy, func(t *testing.T) {
+			f := New(DefaultOne, DefaultZero, 100, 1)
+			f.Strategy = strategy
+			f.Source = source
+			if err := f.LoadTarget(strings.NewReader(strings.Join(cross, "\n") + "\n")); err != nil {
+				t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+			}
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			want := []Match{{X: 7, Y: 4, Percentage: 100}}
+			if !reflect.DeepEqual(f.Matches, want) {
+				t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want)
+			}
+		})
+	}
+}
+
+func Test_topMatches(t *testing.T) {
+	matches := []Match{
+		{X: 5, Y: 0, Percentage: 80},
+		{X: 1, Y: 2, Percentage: 95},
+		{X: 0, Y: 2, Percentage: 80},
+		{X: 3, Y: 1, Percentage: 80},
+	}
+	tests := []struct {
+		name string
+		k    int
+		want []Match
+	}{
+		{"one", 1, []Match{{X: 1, Y: 2, Percentage: 95}}},
+		{"ties by position", 3, []Match{{X: 1, Y: 2, Percentage: 95}, {X: 5, Y: 0, Percentage: 80}, {X: 3, Y: 1, Percentage: 80}}},
+		{"more than matches", 10, []Match{{X: 1, Y: 2, Percentage: 95}, {X: 5, Y: 0, Percentage: 80}, {X: 3, Y: 1, Percentage: 80}, {X: 0, Y: 2, Percentage: 80}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topMatches(matches, tt.k); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("topMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Search_topK(t *testing.T) {
+	all := loadTestFinder(t, 70, 1)
+	if err := all.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+
+	f := loadTestFinder(t, 70, 1)
+	f.TopK = 3
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if want := topMatches(all.Matches, 3); !reflect.DeepEqual(f.Matches, want) {
+		t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want)
+	}
+
+	// nothing clears the threshold, the best candidate is the only match
+	f.Percentage = 100.5
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if want := topMatches(all.Matches, 1); !reflect.DeepEqual(f.Matches, want) {
+		t.Errorf("Finder2D.Search() without matches = %v, want %v", f.Matches, want)
+	}
+
+	f.TopK = -1
+	if err := f.Search(); err == nil {
+		t.Errorf("Finder2D.Search() with top K -1 expected an error")
+	}
+}
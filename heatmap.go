@@ -0,0 +1,166 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// heatmapLevels is the number of gray levels of the PGM heatmap, the scores
+// are in tenths of percentage
+const heatmapLevels = 1000
+
+// HeatmapFormats are the formats of the heatmap exports
+var HeatmapFormats = []string{"text", "csv", "pgm"}
+
+// Heatmap is the score surface of the target in the source, the score of the
+// target at every position of the source where the target fits
+type Heatmap struct {
+	// Scores are the percentage, in the finder metric, of the target with the
+	// top-left corner at every position, by rows
+	Scores [][]float64
+}
+
+// Heatmap returns the score of the target at every position of the source,
+// with the metric, comparison and tolerance of the finder, whether the score
+// clears the percentage or not. The target is compared only in its original
+// orientation and size, the targets of the library are not used
+func (f *Finder2D) Heatmap(ctx context.Context) (*Heatmap, error) {
+	if f.Source == nil {
+		return nil, fmt.Errorf("not set source matrix")
+	}
+	if f.Target == nil {
+		return nil, fmt.Errorf("not set target matrix")
+	}
+	params, err := f.searchParams()
+	if err != nil {
+		return nil, err
+	}
+
+	maxX, maxY := f.Source.Size()
+	width, height := f.Target.Size()
+	if width > maxX || height > maxY {
+		return nil, fmt.Errorf("target matrix (%d,%d) is larger than the source matrix (%d,%d)", width, height, maxX, maxY)
+	}
+	h := &Heatmap{Scores: make([][]float64, maxY-height+1)}
+	for y := range h.Scores {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		h.Scores[y] = make([]float64, maxX-width+1)
+		for x := range h.Scores[y] {
+			h.Scores[y][x] = params.Metric.Score(f.Source.confusionAt(x, y, f.Target, params))
+		}
+	}
+	return h, nil
+}
+
+// Size returns the number of positions of the heatmap, in columns and rows
+func (h *Heatmap) Size() (int, int) {
+	if len(h.Scores) == 0 {
+		return 0, 0
+	}
+	return len(h.Scores[0]), len(h.Scores)
+}
+
+// Matrix returns the heatmap as a matrix of values, see NewValuesMatrix
+func (h *Heatmap) Matrix() (*Matrix, error) {
+	return NewValuesMatrix(h.Scores)
+}
+
+// lowest returns the lowest possible score, -100 if any score is negative,
+// like with the NCC metric, otherwise 0
+func (h *Heatmap) lowest() float64 {
+	for _, row := range h.Scores {
+		for _, s := range row {
+			if s < 0 {
+				return -100
+			}
+		}
+	}
+	return 0
+}
+
+// Encode writes the heatmap in the given format: `text`, `csv` or `pgm`
+func (h *Heatmap) Encode(w io.Writer, format string) error {
+	switch format {
+	case "", "text":
+		_, err := io.WriteString(w, h.String())
+		return err
+	case "csv":
+		return h.EncodeCSV(w)
+	case "pgm":
+		return h.EncodePGM(w, false)
+	}
+	return fmt.Errorf("unknown heatmap format %q. Available formats are: %s", format, strings.Join(HeatmapFormats, ", "))
+}
+
+// EncodeCSV writes the scores of the heatmap as CSV, a record per row
+func (h *Heatmap) EncodeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	for _, row := range h.Scores {
+		record := make([]string, len(row))
+		for x, s := range row {
+			record[x] = strconv.FormatFloat(s, 'f', -1, 64)
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// EncodePGM writes the heatmap as a PGM image, plain or raw, the brighter the
+// position the higher the score. The scores are in tenths of percentage from
+// 0%, or -100% if any score is negative, so the maximum gray value is the
+// highest score
+func (h *Heatmap) EncodePGM(w io.Writer, plain bool) error {
+	lo := h.lowest()
+	levels := make([][]float64, len(h.Scores))
+	for y, row := range h.Scores {
+		levels[y] = make([]float64, len(row))
+		for x, s := range row {
+			levels[y][x] = math.Round((s - lo) * heatmapLevels / (100 - lo))
+		}
+	}
+	m, err := NewValuesMatrix(levels)
+	if err != nil {
+		return err
+	}
+	return EncodePGM(w, m, plain)
+}
+
+// String returns the heatmap shaded for the terminal, a position per cell in
+// shades of gray from black for the lowest score to white for 100%
+func (h *Heatmap) String() string {
+	lo := h.lowest()
+	var b bytes.Buffer
+	for _, row := range h.Scores {
+		for _, s := range row {
+			shade := 232 + int(math.Round((s-lo)*23/(100-lo)))
+			fmt.Fprintf(&b, "\033[48;5;%dm \033[0m", shade)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,100 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFinder2D_Heatmap(t *testing.T) {
+	f := loadTestFinder(t, 70, 1)
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	h, err := f.Heatmap(context.Background())
+	if err != nil {
+		t.Fatalf("Finder2D.Heatmap() error = %v", err)
+	}
+
+	maxX, maxY := f.Source.Size()
+	width, height := f.Target.Size()
+	if gotW, gotH := h.Size(); gotW != maxX-width+1 || gotH != maxY-height+1 {
+		t.Fatalf("Heatmap.Size() = (%d,%d), want (%d,%d)", gotW, gotH, maxX-width+1, maxY-height+1)
+	}
+	// the score of every match is the score in the heatmap
+	for _, m := range f.Matches {
+		if got := h.Scores[m.Y][m.X]; math.Abs(got-m.Percentage) > 1e-9 {
+			t.Errorf("Heatmap.Scores[%d][%d] = %v, want %v", m.Y, m.X, got, m.Percentage)
+		}
+	}
+	// and it's the same score of a sample of the source
+	sample := f.Source.Sample(7, 3, width, height)
+	want, _ := sample.Compare(f.Target)
+	if got := h.Scores[3][7]; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Heatmap.Scores[3][7] = %v, want %v", got, want)
+	}
+
+	f.Target = newTestMatrix(t, make([][]int, maxY+1))
+	if _, err := f.Heatmap(context.Background()); err == nil {
+		t.Errorf("Finder2D.Heatmap() of a larger target error = nil, want an error")
+	}
+	if _, err := New(DefaultOne, DefaultZero, 50, 1).Heatmap(context.Background()); err == nil {
+		t.Errorf("Finder2D.Heatmap() without matrixes error = nil, want an error")
+	}
+}
+
+func TestHeatmap_Encode(t *testing.T) {
+	h := &Heatmap{Scores: [][]float64{{0, 50}, {87.5, 100}}}
+	ncc := &Heatmap{Scores: [][]float64{{-100, 0}, {50, 100}}}
+	tests := []struct {
+		name    string
+		heatmap *Heatmap
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"csv", h, "csv", "0,50\n87.5,100\n", false},
+		{"pgm", h, "pgm", "P5\n2 2\n1000\n\x00\x00\x01\xf4\x03\x6b\x03\xe8", false},
+		{"pgm with negative scores", ncc, "pgm", "P5\n2 2\n1000\n\x00\x00\x01\xf4\x02\xee\x03\xe8", false},
+		{"text", h, "text", "\033[48;5;232m \033[0m\033[48;5;244m \033[0m\n\033[48;5;252m \033[0m\033[48;5;255m \033[0m\n", false},
+		{"unknown format", h, "png", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.heatmap.Encode(&b, tt.format); (err != nil) != tt.wantErr {
+				t.Fatalf("Heatmap.Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := b.String(); !tt.wantErr && got != tt.want {
+				t.Errorf("Heatmap.Encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// the plain PGM is readable
+	var b bytes.Buffer
+	if err := h.EncodePGM(&b, true); err != nil {
+		t.Fatalf("Heatmap.EncodePGM() error = %v", err)
+	}
+	if got, want := b.String(), "P2\n2 2\n1000\n0 500\n875 1000\n"; !strings.HasPrefix(got, want) {
+		t.Errorf("Heatmap.EncodePGM() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register the GIF decoder
+	_ "image/png" // register the PNG decoder
+	"io"
+	"math"
+	"strconv"
+)
+
+// Threshold is how an image is converted into a matrix. A threshold from 1 to
+// 255 is the luminance of the binary cells, the pixels darker than it are the
+// ones and the others are the zeros
+type Threshold int
+
+// The thresholds computed or without a fixed luminance
+const (
+	// ThresholdOtsu computes the luminance threshold of every image with the
+	// Otsu's method, it's the default threshold
+	ThresholdOtsu Threshold = 0
+	// ThresholdGray converts the image into a matrix of values, the luminance
+	// of every pixel from 0 to 255, instead of a binary matrix
+	ThresholdGray Threshold = -1
+)
+
+const (
+	thresholdOtsuName = "otsu"
+	thresholdGrayName = "gray"
+)
+
+func (t Threshold) String() string {
+	switch t {
+	case ThresholdOtsu:
+		return thresholdOtsuName
+	case ThresholdGray:
+		return thresholdGrayName
+	}
+	return strconv.Itoa(int(t))
+}
+
+// ParseThreshold returns the threshold with the given name or luminance, an
+// empty name is the default threshold `ThresholdOtsu`
+func ParseThreshold(name string) (Threshold, error) {
+	switch name {
+	case "", thresholdOtsuName:
+		return ThresholdOtsu, nil
+	case thresholdGrayName:
+		return ThresholdGray, nil
+	}
+	t, err := strconv.Atoi(name)
+	if err != nil || t < 1 || t > 255 {
+		return ThresholdOtsu, fmt.Errorf("unknown threshold %q. Available thresholds are: %s, %s or a luminance from 1 to 255", name, thresholdOtsuName, thresholdGrayName)
+	}
+	return Threshold(t), nil
+}
+
+// imageMagics are the magic numbers of the supported image formats
+var imageMagics = [][]byte{
+	[]byte("\x89PNG\r\n\x1a\n"),
+	[]byte("GIF87a"),
+	[]byte("GIF89a"),
+}
+
+// IsImage returns true if the reader starts with the magic number of a PNG or
+// GIF image. The reader is not consumed
+func IsImage(r *bufio.Reader) bool {
+	for _, magic := range imageMagics {
+		if b, err := r.Peek(len(magic)); err == nil && bytes.Equal(b, magic) {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeImage creates a matrix from a PNG or GIF image. With `ThresholdGray`
+// the matrix has the luminance of every pixel as numeric values, see
+// NewValuesMatrix, otherwise it's a binary matrix where the pixels darker than
+// the threshold are the ones. The fully transparent pixels are ignored cells.
+// Only the first frame of an animated GIF is decoded
+func DecodeImage(r io.Reader, threshold Threshold) (*Matrix, error) {
+	if threshold < ThresholdGray || threshold > 255 {
+		return nil, fmt.Errorf("invalid threshold %d, it has to be a luminance from 1 to 255", threshold)
+	}
+	// the size is checked before decoding, the header may be of a huge image
+	var header bytes.Buffer
+	config, _, err := image.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the image. %s", err)
+	}
+	if err := checkImageSize(config.Width, config.Height); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the image. %s", err)
+	}
+
+	lum := luminance(img)
+	if threshold == ThresholdGray {
+		values := make([][]float64, len(lum))
+		for y, row := range lum {
+			values[y] = make([]float64, len(row))
+			for x, l := range row {
+				if l < 0 {
+					values[y][x] = math.NaN()
+					continue
+				}
+				values[y][x] = float64(l)
+			}
+		}
+		return NewValuesMatrix(values)
+	}
+
+	if threshold == ThresholdOtsu {
+		threshold = otsu(lum)
+	}
+	content := make([][]int, len(lum))
+	for y, row := range lum {
+		content[y] = make([]int, len(row))
+		for x, l := range row {
+			switch {
+			case l < 0:
+				content[y][x] = Ignored
+			case l < int(threshold):
+				content[y][x] = 1
+			}
+		}
+	}
+	return NewMatrix(content)
+}
+
+// luminance returns the luminance, from 0 to 255, of every pixel of the image
+// or -1 if the pixel is fully transparent
+func luminance(img image.Image) [][]int {
+	b := img.Bounds()
+	lum := make([][]int, b.Dy())
+	for y := range lum {
+		lum[y] = make([]int, b.Dx())
+		for x := range lum[y] {
+			c := img.At(b.Min.X+x, b.Min.Y+y)
+			if _, _, _, a := c.RGBA(); a == 0 {
+				lum[y][x] = -1
+				continue
+			}
+			lum[y][x] = int(color.GrayModel.Convert(c).(color.Gray).Y)
+		}
+	}
+	return lum
+}
+
+// otsu returns the threshold that maximizes the variance between the dark and
+// the light pixels. If every pixel has the same luminance the threshold is
+// the middle luminance
+func otsu(lum [][]int) Threshold {
+	var hist [256]int
+	total, sum := 0, 0
+	for _, row := range lum {
+		for _, l := range row {
+			if l < 0 {
+				continue
+			}
+			hist[l]++
+			total++
+			sum += l
+		}
+	}
+
+	threshold, best := Threshold(128), 0.0
+	dark, darkSum := 0, 0
+	for l := 0; l < 255; l++ {
+		dark += hist[l]
+		darkSum += l * hist[l]
+		light := total - dark
+		if dark == 0 || light == 0 {
+			continue
+		}
+		mDark := float64(darkSum) / float64(dark)
+		mLight := float64(sum-darkSum) / float64(light)
+		variance := float64(dark) * float64(light) * (mDark - mLight) * (mDark - mLight)
+		if variance > best {
+			// the dark pixels are the ones with luminance up to l
+			threshold, best = Threshold(l+1), variance
+		}
+	}
+	return threshold
+}
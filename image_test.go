@@ -0,0 +1,193 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bufio"
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testImage returns an image with the given luminance of every pixel, a
+// negative luminance is a transparent pixel
+func testImage(lum [][]int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, len(lum[0]), len(lum)))
+	for y, row := range lum {
+		for x, l := range row {
+			if l < 0 {
+				continue
+			}
+			img.Set(x, y, color.Gray{Y: uint8(l)})
+		}
+	}
+	return img
+}
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Threshold
+		wantErr bool
+	}{
+		{"", ThresholdOtsu, false},
+		{"otsu", ThresholdOtsu, false},
+		{"gray", ThresholdGray, false},
+		{"128", Threshold(128), false},
+		{"0", ThresholdOtsu, true},
+		{"256", ThresholdOtsu, true},
+		{"mean", ThresholdOtsu, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseThreshold(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseThreshold() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseThreshold() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeImage(t *testing.T) {
+	lum := [][]int{
+		{10, 200, 30, 220},
+		{250, 20, -1, 40},
+	}
+	var pngImage, gifImage bytes.Buffer
+	if err := png.Encode(&pngImage, testImage(lum)); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	if err := gif.Encode(&gifImage, testImage([][]int{{0, 255}, {255, 0}}), nil); err != nil {
+		t.Fatalf("gif.Encode() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		image      []byte
+		threshold  Threshold
+		want       [][]int
+		wantValues [][]float64
+		wantErr    bool
+	}{
+		{"PNG Otsu", pngImage.Bytes(), ThresholdOtsu, [][]int{{1, 0, 1, 0}, {0, 1, Ignored, 1}}, nil, false},
+		{"PNG fixed", pngImage.Bytes(), Threshold(25), [][]int{{1, 0, 0, 0}, {0, 1, Ignored, 0}}, nil, false},
+		{"PNG gray", pngImage.Bytes(), ThresholdGray, nil, [][]float64{{10, 200, 30, 220}, {250, 20, math.NaN(), 40}}, false},
+		{"GIF", gifImage.Bytes(), Threshold(128), [][]int{{1, 0}, {0, 1}}, nil, false},
+		{"invalid threshold", pngImage.Bytes(), Threshold(256), nil, nil, true},
+		{"not an image", []byte("+ +\n + \n"), ThresholdOtsu, nil, nil, true},
+		{"huge image", []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00"), ThresholdOtsu, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := DecodeImage(bytes.NewReader(tt.image), tt.threshold)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantValues != nil {
+				want, _ := NewValuesMatrix(tt.wantValues)
+				if !m.equalValues(want) {
+					t.Errorf("DecodeImage() values = %v, want %v", m.Values(), tt.wantValues)
+				}
+				return
+			}
+			if !reflect.DeepEqual(m.Content(), tt.want) || !m.binary() {
+				t.Errorf("DecodeImage() content = %v, want %v", m.Content(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_otsu(t *testing.T) {
+	tests := []struct {
+		name string
+		lum  [][]int
+		want Threshold
+	}{
+		{"two levels", [][]int{{0, 0, 255, 255}}, Threshold(1)},
+		{"two clusters", [][]int{{10, 20, 30}, {200, 210, 220}}, Threshold(31)},
+		{"transparent pixels", [][]int{{10, -1, 30}, {200, -1, 220}}, Threshold(31)},
+		{"one level", [][]int{{100, 100}}, Threshold(128)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := otsu(tt.lum); got != tt.want {
+				t.Errorf("otsu() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Load_image(t *testing.T) {
+	want := loadTestFinder(t, 70, 1)
+	if err := want.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+
+	// the test matrixes as black and white PNG and GIF images
+	toLum := func(m *Matrix) [][]int {
+		lum := make([][]int, len(m.Content()))
+		for y, row := range m.Content() {
+			lum[y] = make([]int, len(row))
+			for x, v := range row {
+				lum[y][x] = 255 * (1 - v)
+			}
+		}
+		return lum
+	}
+	var source, target bytes.Buffer
+	if err := png.Encode(&source, testImage(toLum(want.Source))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	if err := gif.Encode(&target, testImage(toLum(want.Target)), nil); err != nil {
+		t.Fatalf("gif.Encode() error = %v", err)
+	}
+	if !IsImage(bufio.NewReader(bytes.NewReader(source.Bytes()))) || !IsImage(bufio.NewReader(bytes.NewReader(target.Bytes()))) {
+		t.Fatalf("IsImage() = false, want true")
+	}
+	if IsImage(bufio.NewReader(strings.NewReader("P1 1 1 1"))) {
+		t.Errorf("IsImage() of a PBM image = true, want false")
+	}
+
+	f := New(DefaultOne, DefaultZero, 70, 1)
+	if err := f.LoadSource(&source); err != nil {
+		t.Fatalf("Finder2D.LoadSource() error = %v", err)
+	}
+	if err := f.LoadTarget(&target); err != nil {
+		t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+	}
+	if !f.Source.equal(want.Source) || !f.Target.equal(want.Target) {
+		t.Fatalf("Finder2D loaded matrixes are different to the text matrixes")
+	}
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if !reflect.DeepEqual(f.Matches, want.Matches) {
+		t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want.Matches)
+	}
+}
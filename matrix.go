@@ -17,110 +17,381 @@ limitations under the License.
 package finder2d
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"io"
+	"unicode/utf8"
 )
 
 const (
-	uno  = "\033[44m \033[0m" // Blue // `◻️`
-	cero = "\033[40m \033[0m" // Black // `◼️️`
+	uno  = "\033[44m \033[0m"  // Blue // `◻️`
+	cero = "\033[40m \033[0m"  // Black // `◼️️`
+	nulo = "\033[100m \033[0m" // Gray
 )
 
-// Matrix represents a 2D array
+// Ignored is the value of a don't-care or wildcard cell. An ignored cell is
+// not compared, it's excluded from the match percentage
+const Ignored = -1
+
+// DefaultIgnore is the default value for an ignored cell in a matrix
+var DefaultIgnore = '?'
+
+// symbols is the value of the cell of every character of a loaded matrix
+type symbols map[rune]int
+
+// isVariationSelector returns true if the character is a text or emoji
+// variation selector, which is part of the previous character, i.e. `◻️`
+func isVariationSelector(c rune) bool {
+	return c == '\uFE0E' || c == '\uFE0F'
+}
+
+// binarySymbols returns the symbols table of the `one` and `zero` values, and
+// the `ignore` value if the ignored cells are enabled
+func binarySymbols(one, zero, ignore rune, withIgnore bool) symbols {
+	s := symbols{}
+	if withIgnore {
+		s[ignore] = Ignored
+	}
+	s[zero] = 0
+	s[one] = 1
+	return s
+}
+
+// ParseCell returns the character of a cell value given in a string, i.e. a
+// flag, which has to be a single character, optionally with a variation
+// selector. An empty string is `0`
+func ParseCell(s string) (rune, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+	c, size := utf8.DecodeRuneInString(s)
+	rest := s[size:]
+	if vs, n := utf8.DecodeRuneInString(rest); isVariationSelector(vs) {
+		rest = rest[n:]
+	}
+	if c == utf8.RuneError || len(rest) != 0 {
+		return 0, fmt.Errorf("cell value %q has to be a single character", s)
+	}
+	return c, nil
+}
+
+// Matrix represents a 2D array. The cells are `1`, `0` or `Ignored`, or a
+// level of an alphabet for matrixes with more than two levels, or the level of
+// a numeric value in its range for matrixes of values. The cells are packed in
+// bits rows, a bit per cell that is a one and another per cell not ignored,
+// the bits are the backing store of a binary matrix and the rows compared. A
+// matrix with more than two levels keeps the level of every cell too
 type Matrix struct {
-	Content    [][]int
 	maxX, maxY int
+	levels     int
+	bits       []bitRow
+	// care has the cells that are not ignored, it's nil if no cell is ignored
+	care []bitRow
+	ones int
+	// cells are the level of every cell, it's nil if the matrix is binary
+	cells [][]int
+	// weights are the weight of every cell, packed by weight in classes
+	weights [][]float64
+	classes []weightClass
+	// values are the numeric value of every cell in the range from lo to hi,
+	// it's nil if the cells are not numeric
+	values [][]float64
+	lo, hi float64
+}
+
+// NewMatrix creates a matrix with the given content. All the rows have to be
+// the same width. The number of levels is the highest cell plus one, at least 2
+func NewMatrix(content [][]int) (*Matrix, error) {
+	m := &Matrix{}
+	if len(content) == 0 {
+		return m, nil
+	}
+	maxX, levels := len(content[0]), 2
+	for y, row := range content {
+		if len(row) != maxX {
+			return nil, fmt.Errorf("matrix width = %d, especified by the first row, is different at row #%d (%d)", maxX, y, len(row))
+		}
+		for _, v := range row {
+			if v >= levels {
+				levels = v + 1
+			}
+		}
+	}
+	m.setContent(content, maxX, len(content), levels)
+
+	return m, nil
 }
 
 // LoadMatrix create a matrix from a reader
-func LoadMatrix(r io.Reader, one, zero byte) (*Matrix, error) {
+func LoadMatrix(r io.Reader, one, zero rune) (*Matrix, error) {
 	m := &Matrix{}
 	err := m.Load(r, one, zero)
 	return m, err
 }
 
+// LoadMatrixWithIgnore create a matrix from a reader with ignored cells
+func LoadMatrixWithIgnore(r io.Reader, one, zero, ignore rune) (*Matrix, error) {
+	m := &Matrix{}
+	err := m.LoadWithIgnore(r, one, zero, ignore)
+	return m, err
+}
+
 // Load loads a matrix from a reader replacing the cell value given in `one`
-// for `1` and `zero` for `0`
-func (m *Matrix) Load(r io.Reader, one, zero byte) error {
-	var x, y int
-	NL := []byte("\n")[0]
-
-	defer func() {
-		if m.Content == nil || m.maxX+m.maxY == 0 {
-			m.Content = nil
-			m.maxX, m.maxY = 0, 0
-		}
-	}()
+// for `1` and `zero` for `0`. The matrix is UTF-8 text, the values are
+// characters and the width of the rows is the number of characters
+func (m *Matrix) Load(r io.Reader, one, zero rune) error {
+	return m.load(r, binarySymbols(one, zero, 0, false), 2)
+}
+
+// LoadWithIgnore is like Load but also replaces the cell value given in
+// `ignore` for `Ignored`
+func (m *Matrix) LoadWithIgnore(r io.Reader, one, zero, ignore rune) error {
+	return m.load(r, binarySymbols(one, zero, ignore, true), 2)
+}
 
-	m.Content = [][]int{[]int{}}
-	buf := make([]byte, 10)
+// load loads a matrix from a reader replacing every character for its value
+// in the symbols table. The matrix cells have the given number of levels. The
+// variation selectors are skipped, they are part of the previous character.
+// The errors have the row and column, from 1, of the offending character
+func (m *Matrix) load(r io.Reader, s symbols, levels int) error {
+	var x, y, maxX int
+
+	*m = Matrix{}
+	content := [][]int{[]int{}}
+	br := bufio.NewReader(r)
 	for {
-		n, errRead := r.Read(buf)
-		for i := 0; i < n; i++ {
-			switch buf[i] {
-			case byte(NL):
-				if m.maxX == 0 {
-					m.maxX = x
-				} else if x > m.maxX {
-					m.Content = nil
-					return fmt.Errorf("source width = %d, especified by the first row, is larger at line #%d (%d)", m.maxX, y, x)
-				} else if x < m.maxX {
-					for i := 0; i < m.maxX-x; i++ {
-						m.Content[y] = append(m.Content[y], 0)
-					}
-				}
-				x = 0
-				y = y + 1
-				m.Content = append(m.Content, []int{})
-			case one:
-				x = x + 1
-				m.Content[y] = append(m.Content[y], 1)
-			case zero:
-				x = x + 1
-				m.Content[y] = append(m.Content[y], 0)
-			default:
-				m.Content = nil
-				return fmt.Errorf("found invalid value in the source matrix %q", buf[i])
-			}
-		}
+		c, size, errRead := br.ReadRune()
 		if errRead == io.EOF {
 			break
 		}
+		if errRead != nil {
+			return errRead
+		}
+		switch {
+		case c == utf8.RuneError && size == 1:
+			return fmt.Errorf("found invalid UTF-8 character in the matrix at row #%d, column #%d", y+1, x+1)
+		case isVariationSelector(c):
+			continue
+		case c == '\n':
+			if maxX == 0 {
+				maxX = x
+			} else if x > maxX {
+				return fmt.Errorf("matrix width = %d, especified by the first row, is larger at row #%d (%d)", maxX, y+1, x)
+			} else if x < maxX {
+				for i := 0; i < maxX-x; i++ {
+					content[y] = append(content[y], 0)
+				}
+			}
+			x = 0
+			y = y + 1
+			content = append(content, []int{})
+		default:
+			v, ok := s[c]
+			if !ok {
+				return fmt.Errorf("found invalid value %q in the matrix at row #%d, column #%d", c, y+1, x+1)
+			}
+			x = x + 1
+			content[y] = append(content[y], v)
+		}
 	}
 
-	if y > 0 && len(m.Content[y]) == 0 {
-		m.Content = m.Content[:len(m.Content)-1]
+	if y > 0 && len(content[y]) == 0 {
+		content = content[:len(content)-1]
 		y = y - 1
 	}
 	if y != 0 {
 		y = y + 1
 	}
-	m.maxY = y
+	if maxX+y == 0 {
+		return nil
+	}
+	// the last row may not end with a new line
+	if last := content[len(content)-1]; len(last) > maxX {
+		return fmt.Errorf("matrix width = %d, especified by the first row, is larger at row #%d (%d)", maxX, len(content), len(last))
+	} else if len(last) < maxX {
+		content[len(content)-1] = append(last, make([]int, maxX-len(last))...)
+	}
+	m.setContent(content, maxX, y, levels)
 
 	return nil
 }
 
+// setContent sets the size, the number of levels and the cells of the matrix.
+// The rows of the content have to be the given width. The content is packed
+// in bits rows and it's kept only if the matrix has more than two levels
+func (m *Matrix) setContent(content [][]int, maxX, maxY, levels int) {
+	m.maxX, m.maxY = maxX, maxY
+	m.levels = levels
+	m.cells = nil
+	if m.Levels() > 2 {
+		m.cells = content
+	}
+	m.pack(content)
+}
+
+// pack packs the content rows into bits, and the cells not ignored if there
+// is any ignored cell
+func (m *Matrix) pack(content [][]int) {
+	m.bits, m.care, m.ones = nil, nil, 0
+	if m.maxX+m.maxY == 0 {
+		return
+	}
+	m.bits = make([]bitRow, len(content))
+	ignored := false
+	for y, row := range content {
+		m.bits[y] = packRow(row)
+		for _, v := range row {
+			switch v {
+			case 1:
+				m.ones++
+			case Ignored:
+				ignored = true
+			}
+		}
+	}
+	if !ignored {
+		return
+	}
+	m.care = make([]bitRow, len(content))
+	for y, row := range content {
+		m.care[y] = packCareRow(row)
+	}
+}
+
+// Cell returns the value of the cell (x,y), `1`, `0` or `Ignored`, or a level
+// of a matrix with more than two levels. The cell has to be in the matrix
+func (m *Matrix) Cell(x, y int) int {
+	if m.cells != nil {
+		return m.cells[y][x]
+	}
+	word, bit := x/wordSize, uint64(1)<<uint(x%wordSize)
+	if m.care != nil && m.care[y][word]&bit == 0 {
+		return Ignored
+	}
+	if m.bits[y][word]&bit != 0 {
+		return 1
+	}
+	return 0
+}
+
+// SetCell sets the value of the cell (x,y), `Ignored` or a level from `0`. A
+// binary matrix has more than two levels if the value is higher than `1`. The
+// numeric value of a cell of a matrix of values is the value of the level in
+// the range of the values. It returns an error if the cell is not in the
+// matrix or the value is not valid
+func (m *Matrix) SetCell(x, y, v int) error {
+	if x < 0 || y < 0 || x >= m.maxX || y >= len(m.bits) {
+		return fmt.Errorf("cell (%d,%d) is not in the matrix (%d,%d)", x, y, m.maxX, m.maxY)
+	}
+	if v < Ignored || (m.values != nil && v >= m.Levels()) {
+		return fmt.Errorf("invalid value %d of the cell (%d,%d)", v, x, y)
+	}
+	if m.cells == nil && v > 1 {
+		m.cells = m.Content()
+	}
+	if v >= m.Levels() {
+		m.levels = v + 1
+	}
+	wasIgnored := m.Cell(x, y) == Ignored
+	if m.cells != nil {
+		m.cells[y][x] = v
+	}
+	if m.values != nil {
+		m.values[y][x] = m.levelValue(v)
+	}
+
+	word, bit := x/wordSize, uint64(1)<<uint(x%wordSize)
+	if m.bits[y][word]&bit != 0 {
+		m.ones--
+	}
+	m.bits[y][word] &^= bit
+	if v == 1 {
+		m.bits[y][word] |= bit
+		m.ones++
+	}
+	switch {
+	case v == Ignored && m.care == nil:
+		m.care = make([]bitRow, len(m.bits))
+		for yi := range m.care {
+			m.care[yi] = fullRow(m.maxX)
+		}
+		m.care[y][word] &^= bit
+	case v == Ignored:
+		m.care[y][word] &^= bit
+	case wasIgnored:
+		m.care[y][word] |= bit
+		if !m.HasIgnored() {
+			m.care = nil
+		}
+	}
+	return nil
+}
+
+// Content returns a copy of the cells of the matrix, a slice of cells per row
+func (m *Matrix) Content() [][]int {
+	if len(m.bits) == 0 {
+		return nil
+	}
+	content := make([][]int, len(m.bits))
+	for y := range content {
+		content[y] = make([]int, m.maxX)
+		if m.cells != nil {
+			copy(content[y], m.cells[y])
+			continue
+		}
+		for x := range content[y] {
+			content[y][x] = m.Cell(x, y)
+		}
+	}
+	return content
+}
+
+// HasIgnored returns true if any cell of the matrix is ignored
+func (m *Matrix) HasIgnored() bool {
+	for _, row := range m.care {
+		for k, w := range row {
+			mask := ^uint64(0)
+			if k == len(row)-1 {
+				mask = lastWordMask(m.maxX)
+			}
+			if w&mask != mask {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Size returns the size of the matrix
 func (m *Matrix) Size() (int, int) {
 	return m.maxX, m.maxY
 }
 
 func (m *Matrix) String() string {
-	return m.Sprintf(cero, uno)
+	return m.SprintfWithIgnore(cero, uno, nulo)
 }
 
 // Sprintf returns a string representing the matrix but using the given `one`,
-// `zero` strings to represent the one and zero values
+// `zero` strings to represent the one and zero values. The ignored cells are
+// represented by `DefaultIgnore`
 func (m *Matrix) Sprintf(zero, one string) string {
+	return m.SprintfWithIgnore(zero, one, string(DefaultIgnore))
+}
+
+// SprintfWithIgnore is like Sprintf but using the given `ignore` string to
+// represent the ignored cells
+func (m *Matrix) SprintfWithIgnore(zero, one, ignore string) string {
 	var b bytes.Buffer
-	for _, row := range m.Content {
-		for _, v := range row {
-			switch v {
+	for y := range m.bits {
+		for x := 0; x < m.maxX; x++ {
+			switch m.Cell(x, y) {
 			case 0:
 				b.WriteString(zero)
 			case 1:
 				b.WriteString(one)
+			case Ignored:
+				b.WriteString(ignore)
 			}
 		}
 		b.WriteString("\n")
@@ -129,9 +400,9 @@ func (m *Matrix) Sprintf(zero, one string) string {
 }
 
 // Sample gets a sample of the Matrix from the coordinates (x,y) and the given
-// width and height
+// width and height, or nil if the sample is not inside the matrix
 func (m *Matrix) Sample(x, y, w, h int) *Matrix {
-	if x+w > m.maxX || y+h > m.maxY {
+	if x < 0 || y < 0 || x+w > m.maxX || y+h > m.maxY {
 		return nil
 	}
 	if w+h == 0 {
@@ -141,33 +412,56 @@ func (m *Matrix) Sample(x, y, w, h int) *Matrix {
 	for yi := 0; yi < h; yi++ {
 		sample[yi] = make([]int, w)
 		for xi := 0; xi < w; xi++ {
-			sample[yi][xi] = m.Content[y+yi][x+xi]
+			sample[yi][xi] = m.Cell(x+xi, y+yi)
 		}
 	}
 
-	return &Matrix{
-		Content: sample,
-		maxX:    w,
-		maxY:    h,
+	sm := &Matrix{}
+	sm.setContent(sample, w, h, m.levels)
+	if m.values != nil {
+		values := make([][]float64, h)
+		for yi := range values {
+			values[yi] = append([]float64{}, m.values[y+yi][x:x+w]...)
+		}
+		sm.setValues(values, m.lo, m.hi)
 	}
+	if m.weights != nil {
+		weights := make([][]float64, h)
+		for yi := range weights {
+			weights[yi] = append([]float64{}, m.weights[y+yi][x:x+w]...)
+		}
+		sm.SetWeights(weights)
+	}
+
+	return sm
 }
 
-// Compare returns the matching percentage between this and the given matrix
+// Compare returns the matching percentage between this and the given matrix.
+// The cells ignored in any of the matrixes are not compared
 func (m *Matrix) Compare(m1 *Matrix) (float64, error) {
+	c, err := m.Confusion(m1)
+	if err != nil {
+		return 0, err
+	}
+	return Agreement.Score(c), nil
+}
+
+// Confusion returns the confusion counts of the cells of this matrix compared
+// with the given matrix, weighted by the weights of the given matrix if any.
+// The cells ignored in any of the matrixes are not compared
+func (m *Matrix) Confusion(m1 *Matrix) (Confusion, error) {
+	return m.ConfusionWith(m1, SearchParams{})
+}
+
+// ConfusionWith is like Confusion but comparing the cells of matrixes with
+// more than two levels or values with the comparison and tolerance of the
+// given parameters
+func (m *Matrix) ConfusionWith(m1 *Matrix, params SearchParams) (Confusion, error) {
 	if m.maxX+m.maxY == 0 || m1.maxX+m1.maxY == 0 {
-		return 0, nil
+		return Confusion{}, nil
 	}
 	if m.maxX != m1.maxX || m.maxY != m1.maxY {
-		return 0, fmt.Errorf("matrix to compare with is not the same size (%d,%d) != (%d,%d)", m.maxX, m.maxY, m1.maxX, m1.maxY)
-	}
-	var same float64
-	for y := 0; y < m.maxY; y++ {
-		for x := 0; x < m.maxY; x++ {
-			if m.Content[y][x] == m1.Content[y][x] { // && (m.Content[y][x] == 1) {
-				same++
-			}
-		}
+		return Confusion{}, fmt.Errorf("matrix to compare with is not the same size (%d,%d) != (%d,%d)", m.maxX, m.maxY, m1.maxX, m1.maxY)
 	}
-
-	return same / float64(m.maxX*m.maxY) * 100.0, nil
+	return m.confusionAt(0, 0, m1, params), nil
 }
@@ -18,6 +18,7 @@ package finder2d
 
 import (
 	"bytes"
+	"math/rand"
 	"reflect"
 	"testing"
 )
@@ -30,8 +31,8 @@ func TestFinder2D_LoadMatrix(t *testing.T) {
 	}
 	type args struct {
 		content []byte
-		one     byte
-		zero    byte
+		one     rune
+		zero    rune
 	}
 	tests := []struct {
 		name    string
@@ -39,14 +40,14 @@ func TestFinder2D_LoadMatrix(t *testing.T) {
 		want    fields
 		wantErr bool
 	}{
-		{"empty matrix", args{[]byte{}, []byte(`1`)[0], []byte(`0`)[0]}, fields{}, false},
-		{"2x2", args{[]byte("11\n00"), []byte(`1`)[0], []byte(`0`)[0]}, fields{Content: [][]int{{1, 1}, {0, 0}}, maxX: 2, maxY: 2}, false},
-		{"3x3", args{[]byte("101\n010\n110"), []byte(`1`)[0], []byte(`0`)[0]}, fields{Content: [][]int{{1, 0, 1}, {0, 1, 0}, {1, 1, 0}}, maxX: 3, maxY: 3}, false},
-		{"fillable", args{[]byte("101\n00\n1\n"), []byte(`1`)[0], []byte(`0`)[0]}, fields{Content: [][]int{{1, 0, 1}, {0, 0, 0}, {1, 0, 0}}, maxX: 3, maxY: 3}, false},
-		{"empty lines", args{[]byte("11\n00\n\n\n"), []byte(`1`)[0], []byte(`0`)[0]}, fields{Content: [][]int{{1, 1}, {0, 0}, {0, 0}, {0, 0}}, maxX: 2, maxY: 4}, false},
-		{"irregular", args{[]byte("101\n0000\n1\n"), []byte(`1`)[0], []byte(`0`)[0]}, fields{}, true},
-		{"invalid value", args{[]byte("101\n0x0\n1yz\n"), []byte(`1`)[0], []byte(`0`)[0]}, fields{}, true},
-		{"4x3", args{[]byte("1010\n0101\n1100\n"), []byte(`1`)[0], []byte(`0`)[0]}, fields{Content: [][]int{{1, 0, 1, 0}, {0, 1, 0, 1}, {1, 1, 0, 0}}, maxX: 4, maxY: 3}, false},
+		{"empty matrix", args{[]byte{}, '1', '0'}, fields{}, false},
+		{"2x2", args{[]byte("11\n00"), '1', '0'}, fields{Content: [][]int{{1, 1}, {0, 0}}, maxX: 2, maxY: 2}, false},
+		{"3x3", args{[]byte("101\n010\n110"), '1', '0'}, fields{Content: [][]int{{1, 0, 1}, {0, 1, 0}, {1, 1, 0}}, maxX: 3, maxY: 3}, false},
+		{"fillable", args{[]byte("101\n00\n1\n"), '1', '0'}, fields{Content: [][]int{{1, 0, 1}, {0, 0, 0}, {1, 0, 0}}, maxX: 3, maxY: 3}, false},
+		{"empty lines", args{[]byte("11\n00\n\n\n"), '1', '0'}, fields{Content: [][]int{{1, 1}, {0, 0}, {0, 0}, {0, 0}}, maxX: 2, maxY: 4}, false},
+		{"irregular", args{[]byte("101\n0000\n1\n"), '1', '0'}, fields{}, true},
+		{"invalid value", args{[]byte("101\n0x0\n1yz\n"), '1', '0'}, fields{}, true},
+		{"4x3", args{[]byte("1010\n0101\n1100\n"), '1', '0'}, fields{Content: [][]int{{1, 0, 1, 0}, {0, 1, 0, 1}, {1, 1, 0, 0}}, maxX: 4, maxY: 3}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -58,8 +59,119 @@ func TestFinder2D_LoadMatrix(t *testing.T) {
 			if gotX, gotY := m.Size(); gotX != tt.want.maxX || gotY != tt.want.maxY {
 				t.Errorf("Finder2D.Matrix.Size() = (%d,%d), want size (%d,%d)", gotX, gotY, tt.want.maxX, tt.want.maxY)
 			}
-			if !reflect.DeepEqual(m.Content, tt.want.Content) {
-				t.Errorf("Finder2D.Matrix.Load() content = %v, want %v", m.Content, tt.want.Content)
+			if !reflect.DeepEqual(m.Content(), tt.want.Content) {
+				t.Errorf("Finder2D.Matrix.Load() content = %v, want %v", m.Content(), tt.want.Content)
+			}
+		})
+	}
+}
+
+func TestMatrix_LoadWithIgnore(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    [][]int
+		wantErr bool
+	}{
+		{"no ignored", "+ \n +\n", [][]int{{1, 0}, {0, 1}}, false},
+		{"corners", "? ?\n+++\n? ?\n", [][]int{{Ignored, 0, Ignored}, {1, 1, 1}, {Ignored, 0, Ignored}}, false},
+		{"all ignored", "??\n??\n", [][]int{{Ignored, Ignored}, {Ignored, Ignored}}, false},
+		{"invalid value", "?x\n??\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := LoadMatrixWithIgnore(bytes.NewBufferString(tt.content), '+', ' ', '?')
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMatrixWithIgnore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(m.Content(), tt.want) {
+				t.Errorf("LoadMatrixWithIgnore() content = %v, want %v", m.Content(), tt.want)
+			}
+			if got := m.SprintfWithIgnore(" ", "+", "?"); got != tt.content {
+				t.Errorf("Matrix.SprintfWithIgnore() = %q, want %q", got, tt.content)
+			}
+		})
+	}
+
+	if _, err := LoadMatrix(bytes.NewBufferString("? ?\n+++\n"), '+', ' '); err == nil {
+		t.Errorf("LoadMatrix() with ignored cells expected an error")
+	}
+}
+
+func TestMatrix_Load_runes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    [][]int
+		wantErr string
+	}{
+		{"blocks", "█░█\n░█░\n", [][]int{{1, 0, 1}, {0, 1, 0}}, ""},
+		{"emoji", "◻️◼️\n", nil, `found invalid value '◻' in the matrix at row #1, column #1`},
+		{"short row", "██\n█\n", [][]int{{1, 1}, {1, 0}}, ""},
+		{"larger row", "█░\n░█░\n", nil, "matrix width = 2, especified by the first row, is larger at row #2 (3)"},
+		{"invalid value", "█░\n░+\n", nil, `found invalid value '+' in the matrix at row #2, column #2`},
+		{"invalid UTF-8", "█░\n\xff█\n", nil, "found invalid UTF-8 character in the matrix at row #2, column #1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := LoadMatrix(bytes.NewBufferString(tt.content), '█', '░')
+			if len(tt.wantErr) != 0 {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("LoadMatrix() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadMatrix() error = %v", err)
+			}
+			if !reflect.DeepEqual(m.Content(), tt.want) {
+				t.Errorf("LoadMatrix() content = %v, want %v", m.Content(), tt.want)
+			}
+			if w, _ := m.Size(); w != len(tt.want[0]) {
+				t.Errorf("Matrix.Size() width = %d, want %d", w, len(tt.want[0]))
+			}
+		})
+	}
+
+	m, err := LoadMatrix(bytes.NewBufferString("◻️◼️◻️\n◼️◼️◻️\n"), '◻', '◼')
+	if err != nil || !reflect.DeepEqual(m.Content(), [][]int{{1, 0, 1}, {0, 0, 1}}) {
+		t.Errorf("LoadMatrix() with emoji = %v, %v", m.Content(), err)
+	}
+
+	f := New('█', '░', 0, 0)
+	if err := f.LoadTarget(bytes.NewBufferString("█░\n?█\n")); err != nil {
+		t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+	}
+	if got, want := f.Sprintf(f.Target), "█░\n?█\n"; got != want {
+		t.Errorf("Finder2D.Sprintf() = %q, want %q", got, want)
+	}
+}
+
+func TestParseCell(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    rune
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"+", '+', false},
+		{"█", '█', false},
+		{"++", 0, true},
+		{"◻️", '◻', false},
+		{"◻️◼️", 0, true},
+		{"\xff", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseCell(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCell() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCell() = %q, want %q", got, tt.want)
 			}
 		})
 	}
@@ -79,9 +191,9 @@ func TestMatrix_Sample(t *testing.T) {
 	}{
 		{"empty", args{}, &Matrix{}},
 		{"larger sample", args{15, 15, 10, 10}, nil},
-		{"2x2", args{15, 15, 2, 2}, &Matrix{Content: [][]int{{1, 1}, {1, 1}}, maxX: 2, maxY: 2}},
-		{"3x3", args{1, 2, 3, 3}, &Matrix{Content: [][]int{{1, 0, 1}, {1, 1, 1}, {0, 1, 1}}, maxX: 3, maxY: 3}},
-		{"4x4", args{0, 16, 4, 4}, &Matrix{Content: [][]int{{1, 1, 0, 0}, {1, 0, 1, 0}, {1, 0, 0, 1}, {1, 0, 0, 0}}, maxX: 4, maxY: 4}},
+		{"2x2", args{15, 15, 2, 2}, newTestMatrix(t, [][]int{{1, 1}, {1, 1}})},
+		{"3x3", args{1, 2, 3, 3}, newTestMatrix(t, [][]int{{1, 0, 1}, {1, 1, 1}, {0, 1, 1}})},
+		{"4x4", args{0, 16, 4, 4}, newTestMatrix(t, [][]int{{1, 1, 0, 0}, {1, 0, 1, 0}, {1, 0, 0, 1}, {1, 0, 0, 0}})},
 	}
 	m, err := LoadMatrix(bytes.NewBufferString(string(testMatrixData[0])), testMatrixOne, testMatrixZero)
 	if err != nil {
@@ -108,14 +220,16 @@ func TestMatrix_Compare(t *testing.T) {
 		{"diff", testMatrixData[0], testMatrixData[1], 94.0, false},
 		{"diff size", testMatrixData[0], testMatrixData[2], 0, true},
 		{"lot of spaces", testMatrixData[2], testMatrixData[3], 40.0, false},
+		{"ignored", []byte("+?+\n   \n"), []byte("  +\n+ ?\n"), 50.0, false},
+		{"all ignored", []byte("??\n??\n"), []byte("++\n++\n"), 0, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m, err := LoadMatrix(bytes.NewBufferString(string(tt.m)), testMatrixOne, testMatrixZero)
+			m, err := LoadMatrixWithIgnore(bytes.NewBufferString(string(tt.m)), testMatrixOne, testMatrixZero, DefaultIgnore)
 			if err != nil {
 				t.Errorf("Matrix.Compare() failed to load the source matrix. %s", err)
 			}
-			m1, err := LoadMatrix(bytes.NewBufferString(string(tt.m1)), testMatrixOne, testMatrixZero)
+			m1, err := LoadMatrixWithIgnore(bytes.NewBufferString(string(tt.m1)), testMatrixOne, testMatrixZero, DefaultIgnore)
 			if err != nil {
 				t.Errorf("Matrix.Compare() failed to load the target matrix. %s", err)
 			}
@@ -132,6 +246,154 @@ func TestMatrix_Compare(t *testing.T) {
 	}
 }
 
+func TestMatrix_SetCell(t *testing.T) {
+	tests := []struct {
+		name        string
+		x, y, v     int
+		want        float64
+		wantContent [][]int
+		wantErr     bool
+	}{
+		{"not modified", 0, 0, 1, 50, [][]int{{1, 1}, {0, 0}}, false},
+		{"one", 0, 1, 1, 75, [][]int{{1, 1}, {1, 0}}, false},
+		{"zero", 1, 0, 0, 25, [][]int{{1, 0}, {0, 0}}, false},
+		{"ignored", 1, 1, Ignored, 66.66666666666666, [][]int{{1, 1}, {0, Ignored}}, false},
+		{"level", 0, 1, 2, 50, [][]int{{1, 1}, {2, 0}}, false},
+		{"out of the matrix", 2, 0, 1, 50, [][]int{{1, 1}, {0, 0}}, true},
+		{"invalid value", 0, 0, -2, 50, [][]int{{1, 1}, {0, 0}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMatrix(t, [][]int{{1, 1}, {0, 0}})
+			target := newTestMatrix(t, [][]int{{1, 1}, {1, 1}})
+			if err := m.SetCell(tt.x, tt.y, tt.v); (err != nil) != tt.wantErr {
+				t.Fatalf("Matrix.SetCell() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := m.Content(); !reflect.DeepEqual(got, tt.wantContent) {
+				t.Errorf("Matrix.Content() = %v, want %v", got, tt.wantContent)
+			}
+			got, err := m.Compare(target)
+			if err != nil {
+				t.Fatalf("Matrix.Compare() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Matrix.Compare() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// the ignored cells are compared again when they are set
+	m := newTestMatrix(t, [][]int{{1, Ignored}, {0, 0}})
+	if err := m.SetCell(1, 0, 0); err != nil || m.HasIgnored() {
+		t.Errorf("Matrix.SetCell() error = %v, HasIgnored() = %v, want false", err, m.HasIgnored())
+	}
+
+	// the search finds the cells set in the source
+	f := New(DefaultOne, DefaultZero, 100, 1)
+	f.Source = newTestMatrix(t, [][]int{{0, 0, 0}, {0, 0, 0}})
+	f.Target = newTestMatrix(t, [][]int{{1}})
+	if err := f.Source.SetCell(2, 1, 1); err != nil {
+		t.Fatalf("Matrix.SetCell() error = %v", err)
+	}
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if want := []Match{{X: 2, Y: 1, Percentage: 100}}; !reflect.DeepEqual(f.Matches, want) {
+		t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want)
+	}
+}
+
+func TestMatrix_confusionAt(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	randomContent := func(w, h int, ignored bool) [][]int {
+		content := make([][]int, h)
+		for y := range content {
+			content[y] = make([]int, w)
+			for x := range content[y] {
+				content[y][x] = rnd.Intn(2)
+				if ignored && rnd.Intn(4) == 0 {
+					content[y][x] = Ignored
+				}
+			}
+		}
+		return content
+	}
+	randomWeights := func(w, h int) [][]float64 {
+		weights := make([][]float64, h)
+		for y := range weights {
+			weights[y] = make([]float64, w)
+			for x := range weights[y] {
+				weights[y][x] = float64(rnd.Intn(3))
+			}
+		}
+		return weights
+	}
+	tests := []struct {
+		name          string
+		sourceW       int
+		sourceH       int
+		targetW       int
+		targetH       int
+		sourceIgnored bool
+		targetIgnored bool
+		weighted      bool
+	}{
+		{"narrow", 20, 10, 3, 7, false, false, false},
+		{"one word", 100, 10, 64, 3, false, false, false},
+		{"wide", 200, 8, 70, 5, false, false, false},
+		{"full", 130, 4, 130, 4, false, false, false},
+		{"ignored in target", 200, 8, 70, 5, false, true, false},
+		{"ignored in source", 200, 8, 70, 5, true, false, false},
+		{"ignored in both", 130, 6, 65, 4, true, true, false},
+		{"weighted", 200, 8, 70, 5, false, false, true},
+		{"weighted and ignored", 130, 6, 65, 4, true, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newTestMatrix(t, randomContent(tt.sourceW, tt.sourceH, tt.sourceIgnored))
+			target := newTestMatrix(t, randomContent(tt.targetW, tt.targetH, tt.targetIgnored))
+			if tt.weighted {
+				if err := target.SetWeights(randomWeights(tt.targetW, tt.targetH)); err != nil {
+					t.Fatalf("Matrix.SetWeights() error = %v", err)
+				}
+			}
+			for y := 0; y+tt.targetH <= tt.sourceH; y++ {
+				for x := 0; x+tt.targetW <= tt.sourceW; x++ {
+					var want Confusion
+					for yi := 0; yi < tt.targetH; yi++ {
+						for xi := 0; xi < tt.targetW; xi++ {
+							sv, tv := source.Cell(x+xi, y+yi), target.Cell(xi, yi)
+							w := target.weight(xi, yi)
+							switch {
+							case sv == Ignored || tv == Ignored:
+							case sv == 1 && tv == 1:
+								want.TP += w
+							case sv == 0 && tv == 0:
+								want.TN += w
+							case sv == 1:
+								want.FP += w
+							default:
+								want.FN += w
+							}
+						}
+					}
+					if got := source.confusionAt(x, y, target, SearchParams{}); got != want {
+						t.Fatalf("Matrix.confusionAt(%d, %d) = %+v, want %+v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func newTestMatrix(t *testing.T, content [][]int) *Matrix {
+	m, err := NewMatrix(content)
+	if err != nil {
+		t.Fatalf("failed to create the matrix. %s", err)
+	}
+	return m
+}
+
 var testMatrixOne = DefaultOne
 var testMatrixZero = DefaultZero
 var testMatrixData = [][]byte{
@@ -0,0 +1,119 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Metric is the scoring metric used to compare the target with an area of the
+// source. Every metric is a percentage calculated from the confusion counts of
+// the compared cells
+type Metric int
+
+// The scoring metrics
+const (
+	// Agreement is the percentage of equal cells, zeros and ones
+	Agreement Metric = iota
+	// Recall is the percentage of ones of the target that are ones in the source
+	Recall
+	// Jaccard is the intersection over union (IoU) of the ones
+	Jaccard
+	// F1 is the harmonic mean of the precision and recall of the ones
+	F1
+	// NCC is the normalized cross-correlation, it's negative if the area is
+	// more similar to the inverse of the target
+	NCC
+)
+
+// Metrics is the list of all the scoring metrics
+var Metrics = []Metric{Agreement, Recall, Jaccard, F1, NCC}
+
+var metricNames = []string{"agreement", "recall", "jaccard", "f1", "ncc"}
+
+func (mt Metric) String() string {
+	if mt < 0 || int(mt) >= len(metricNames) {
+		return fmt.Sprintf("Metric(%d)", int(mt))
+	}
+	return metricNames[mt]
+}
+
+// ParseMetric returns the metric with the given name, an empty name is the
+// default metric `Agreement`
+func ParseMetric(name string) (Metric, error) {
+	if len(name) == 0 {
+		return Agreement, nil
+	}
+	for i, n := range metricNames {
+		if n == name {
+			return Metric(i), nil
+		}
+	}
+	return Agreement, fmt.Errorf("unknown metric %q. Available metrics are: %s", name, strings.Join(metricNames, ", "))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (mt Metric) MarshalText() ([]byte, error) {
+	return []byte(mt.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (mt *Metric) UnmarshalText(text []byte) error {
+	v, err := ParseMetric(string(text))
+	if err != nil {
+		return err
+	}
+	*mt = v
+	return nil
+}
+
+// Confusion has the (weighted) number of compared cells by the value in the
+// source and in the target: ones in both (TP), zeros in both (TN), ones in the
+// source and zeros in the target (FP) and zeros in the source and ones in the
+// target (FN)
+type Confusion struct {
+	TP, TN, FP, FN float64
+}
+
+// Score returns the percentage of the metric for the given confusion counts.
+// It's 0 if the metric is undefined, i.e. there are no compared cells
+func (mt Metric) Score(c Confusion) float64 {
+	switch mt {
+	case Recall:
+		return ratio(c.TP, c.TP+c.FN)
+	case Jaccard:
+		return ratio(c.TP, c.TP+c.FP+c.FN)
+	case F1:
+		return ratio(2*c.TP, 2*c.TP+c.FP+c.FN)
+	case NCC:
+		n := c.TP + c.TN + c.FP + c.FN
+		s, t := c.TP+c.FP, c.TP+c.FN
+		return ratio(n*c.TP-s*t, math.Sqrt((n*s-s*s)*(n*t-t*t)))
+	default:
+		return ratio(c.TP+c.TN, c.TP+c.TN+c.FP+c.FN)
+	}
+}
+
+// ratio returns the percentage of a over b, or 0 if b is 0
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b * 100.0
+}
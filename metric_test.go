@@ -0,0 +1,163 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMetric_Score(t *testing.T) {
+	c := Confusion{TP: 6, TN: 10, FP: 2, FN: 2}
+	tests := []struct {
+		name   string
+		metric Metric
+		c      Confusion
+		want   float64
+	}{
+		{"agreement", Agreement, c, 80},
+		{"recall", Recall, c, 75},
+		{"jaccard", Jaccard, c, 60},
+		{"f1", F1, c, 75},
+		{"ncc", NCC, c, 100.0 * (20*6 - 8*8) / (8 * 12)},
+		{"ncc inverse", NCC, Confusion{FP: 3, FN: 5}, -100},
+		{"no cells", Agreement, Confusion{}, 0},
+		{"no ones", Recall, Confusion{TN: 4}, 0},
+		{"ncc constant", NCC, Confusion{TP: 4}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.metric.Score(tt.c); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Metric.Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMetric(t *testing.T) {
+	for _, mt := range Metrics {
+		got, err := ParseMetric(mt.String())
+		if err != nil || got != mt {
+			t.Errorf("ParseMetric(%q) = %v, %v, want %v", mt.String(), got, err, mt)
+		}
+	}
+	if got, err := ParseMetric(""); err != nil || got != Agreement {
+		t.Errorf("ParseMetric(\"\") = %v, %v, want %v", got, err, Agreement)
+	}
+	if _, err := ParseMetric("dice"); err == nil {
+		t.Errorf("ParseMetric(\"dice\") expected an error")
+	}
+}
+
+func TestFinder2D_Search_metric(t *testing.T) {
+	// every one of the target is in the source but not every zero, only the
+	// ones recall is 100%
+	source := newTestMatrix(t, [][]int{
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 0},
+		{0, 1, 1, 1, 0},
+		{0, 0, 0, 0, 0},
+	})
+	target := newTestMatrix(t, [][]int{
+		{1, 0, 1},
+		{1, 0, 1},
+	})
+	tests := []struct {
+		metric Metric
+		want   []Match
+	}{
+		{Agreement, []Match{}},
+		{Recall, []Match{{X: 1, Y: 1, Percentage: 100}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.metric.String(), func(t *testing.T) {
+			f := New(DefaultOne, DefaultZero, 100, 1)
+			f.Source, f.Target = source, target
+			f.Metric = tt.metric
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			if len(f.Matches) != len(tt.want) || (len(tt.want) != 0 && f.Matches[0] != tt.want[0]) {
+				t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    [][]float64
+		wantErr bool
+	}{
+		{"spaces", "1 2 3\n0.5 0 1\n", [][]float64{{1, 2, 3}, {0.5, 0, 1}}, false},
+		{"commas and empty lines", "1,2\n\n3,\t4\n", [][]float64{{1, 2}, {3, 4}}, false},
+		{"irregular", "1 2\n3\n", nil, true},
+		{"not a number", "1 x\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadWeights(strings.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadWeights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadWeights() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatrix_SetWeights(t *testing.T) {
+	m := newTestMatrix(t, [][]int{{1, 0, 1}, {0, 1, 1}})
+	tests := []struct {
+		name    string
+		weights [][]float64
+		wantErr bool
+	}{
+		{"valid", [][]float64{{1, 2, 3}, {4, 5, 6}}, false},
+		{"remove", nil, false},
+		{"short", [][]float64{{1, 2, 3}}, true},
+		{"narrow", [][]float64{{1, 2, 3}, {4, 5}}, true},
+		{"negative", [][]float64{{1, 2, 3}, {4, -5, 6}}, true},
+		{"not a number", [][]float64{{1, 2, 3}, {4, math.NaN(), 6}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := m.SetWeights(tt.weights); (err != nil) != tt.wantErr {
+				t.Errorf("Matrix.SetWeights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	weights := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	if err := m.SetWeights(weights); err != nil {
+		t.Fatalf("Matrix.SetWeights() error = %v", err)
+	}
+	if got, want := m.Transform(Rotate90).Weights(), [][]float64{{4, 1}, {5, 2}, {6, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Matrix.Transform() weights = %v, want %v", got, want)
+	}
+	if got, want := m.Resize(6, 2).Weights(), [][]float64{{1, 1, 2, 2, 3, 3}, {4, 4, 5, 5, 6, 6}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Matrix.Resize() weights = %v, want %v", got, want)
+	}
+	if got, want := m.Sample(1, 0, 2, 2).Weights(), [][]float64{{2, 3}, {5, 6}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Matrix.Sample() weights = %v, want %v", got, want)
+	}
+}
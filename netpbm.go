@@ -0,0 +1,414 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"strconv"
+)
+
+// maxPlainLine is the maximum length of the lines of a plain Netpbm image
+const maxPlainLine = 70
+
+// MaxImageCells is the maximum number of cells of a decoded image, larger
+// images are rejected before allocating the matrix
+const MaxImageCells = 1 << 24
+
+// checkImageSize returns an error if an image of the given size has more than
+// `MaxImageCells` cells
+func checkImageSize(w, h int) error {
+	if w < 0 || h < 0 || w > MaxImageCells || h > MaxImageCells || (w != 0 && h > MaxImageCells/w) {
+		return fmt.Errorf("image size %dx%d is too large, the maximum is %d cells", w, h, MaxImageCells)
+	}
+	return nil
+}
+
+// IsNetpbm returns true if the reader starts with the magic number of a PBM
+// (`P1` or `P4`) or PGM (`P2` or `P5`) image. The reader is not consumed
+func IsNetpbm(r *bufio.Reader) bool {
+	magic, err := r.Peek(3)
+	if err != nil {
+		return false
+	}
+	switch string(magic[:2]) {
+	case "P1", "P2", "P4", "P5":
+		return isNetpbmSpace(magic[2])
+	}
+	return false
+}
+
+func isNetpbmSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// DecodeNetpbm creates a matrix from a plain or raw PBM (`P1` or `P4`) or PGM
+// (`P2` or `P5`) image. The PBM pixels are binary cells, a black pixel is a
+// `1`, and the PGM pixels are numeric values, see NewValuesMatrix. Only the
+// first image of the reader is decoded
+func DecodeNetpbm(r io.Reader) (*Matrix, error) {
+	d := &netpbmDecoder{r: bufio.NewReader(r)}
+	magic := make([]byte, 2)
+	if _, err := io.ReadFull(d.r, magic); err != nil {
+		return nil, fmt.Errorf("failed to read the Netpbm magic number. %s", err)
+	}
+	format := string(magic)
+	switch format {
+	case "P1", "P2", "P4", "P5":
+	default:
+		return nil, fmt.Errorf("unknown Netpbm magic number %q, only PBM (P1, P4) and PGM (P2, P5) are supported", format)
+	}
+
+	w, err := d.headerInt("width")
+	if err != nil {
+		return nil, err
+	}
+	h, err := d.headerInt("height")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkImageSize(w, h); err != nil {
+		return nil, err
+	}
+	maxValue := 1
+	if format == "P2" || format == "P5" {
+		if maxValue, err = d.headerInt("maximum value"); err != nil {
+			return nil, err
+		}
+		if maxValue < 1 || maxValue > 65535 {
+			return nil, fmt.Errorf("invalid PGM maximum value %d, it has to be between 1 and 65535", maxValue)
+		}
+	}
+	if format == "P4" || format == "P5" {
+		// a single whitespace separates the header from the raster
+		if _, err := d.r.ReadByte(); err != nil {
+			return nil, fmt.Errorf("failed to read the Netpbm raster. %s", err)
+		}
+	}
+
+	switch format {
+	case "P1":
+		return d.plainPBM(w, h)
+	case "P4":
+		return d.rawPBM(w, h)
+	case "P2":
+		return d.plainPGM(w, h, maxValue)
+	default:
+		return d.rawPGM(w, h, maxValue)
+	}
+}
+
+// netpbmDecoder reads the header and the raster of a Netpbm image
+type netpbmDecoder struct {
+	r *bufio.Reader
+}
+
+// skip skips the whitespaces and comments, from a `#` to the end of the line
+func (d *netpbmDecoder) skip() error {
+	for {
+		c, err := d.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case c == '#':
+			if _, err := d.r.ReadString('\n'); err != nil {
+				return err
+			}
+		case !isNetpbmSpace(c):
+			return d.r.UnreadByte()
+		}
+	}
+}
+
+// headerInt reads the next number of the header
+func (d *netpbmDecoder) headerInt(name string) (int, error) {
+	if err := d.skip(); err != nil {
+		return 0, fmt.Errorf("failed to read the Netpbm %s. %s", name, err)
+	}
+	var digits []byte
+	for {
+		c, err := d.r.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read the Netpbm %s. %s", name, err)
+		}
+		if c < '0' || c > '9' {
+			d.r.UnreadByte()
+			break
+		}
+		digits = append(digits, c)
+	}
+	n, err := strconv.Atoi(string(digits))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid Netpbm %s %q", name, digits)
+	}
+	return n, nil
+}
+
+func (d *netpbmDecoder) plainPBM(w, h int) (*Matrix, error) {
+	content := make([][]int, h)
+	for y := range content {
+		content[y] = make([]int, w)
+		for x := range content[y] {
+			if err := d.skip(); err != nil {
+				return nil, fmt.Errorf("failed to read the PBM pixel (%d,%d). %s", x, y, err)
+			}
+			c, _ := d.r.ReadByte()
+			switch c {
+			case '0':
+			case '1':
+				content[y][x] = 1
+			default:
+				return nil, fmt.Errorf("invalid PBM pixel %q at (%d,%d)", c, x, y)
+			}
+		}
+	}
+	return NewMatrix(content)
+}
+
+func (d *netpbmDecoder) rawPBM(w, h int) (*Matrix, error) {
+	row := make([]byte, (w+7)/8)
+	content := make([][]int, h)
+	for y := range content {
+		if _, err := io.ReadFull(d.r, row); err != nil {
+			return nil, fmt.Errorf("failed to read the PBM row #%d. %s", y, err)
+		}
+		content[y] = make([]int, w)
+		for x := range content[y] {
+			content[y][x] = int(row[x/8]>>uint(7-x%8)) & 1
+		}
+	}
+	return NewMatrix(content)
+}
+
+func (d *netpbmDecoder) plainPGM(w, h, maxValue int) (*Matrix, error) {
+	values := make([][]float64, h)
+	for y := range values {
+		values[y] = make([]float64, w)
+		for x := range values[y] {
+			v, err := d.headerInt("pixel")
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the PGM pixel (%d,%d). %s", x, y, err)
+			}
+			if v > maxValue {
+				return nil, fmt.Errorf("PGM pixel (%d,%d) = %d is higher than the maximum value %d", x, y, v, maxValue)
+			}
+			values[y][x] = float64(v)
+		}
+	}
+	return NewValuesMatrix(values)
+}
+
+func (d *netpbmDecoder) rawPGM(w, h, maxValue int) (*Matrix, error) {
+	size := 1
+	if maxValue > 255 {
+		size = 2
+	}
+	row := make([]byte, w*size)
+	values := make([][]float64, h)
+	for y := range values {
+		if _, err := io.ReadFull(d.r, row); err != nil {
+			return nil, fmt.Errorf("failed to read the PGM row #%d. %s", y, err)
+		}
+		values[y] = make([]float64, w)
+		for x := range values[y] {
+			v := int(row[x*size])
+			if size == 2 {
+				v = v<<8 | int(row[x*size+1])
+			}
+			if v > maxValue {
+				return nil, fmt.Errorf("PGM pixel (%d,%d) = %d is higher than the maximum value %d", x, y, v, maxValue)
+			}
+			values[y][x] = float64(v)
+		}
+	}
+	return NewValuesMatrix(values)
+}
+
+// EncodePBM writes the matrix as a plain (`P1`) or raw (`P4`) PBM image, the
+// ones are black pixels. The matrix has to be binary, the ignored cells are
+// white pixels
+func EncodePBM(w io.Writer, m *Matrix, plain bool) error {
+	if !m.binary() {
+		return fmt.Errorf("only a binary matrix can be encoded as PBM")
+	}
+	bw := bufio.NewWriter(w)
+	width, height := m.Size()
+	if plain {
+		fmt.Fprintf(bw, "P1\n%d %d\n", width, height)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				if x != 0 && x%maxPlainLine == 0 {
+					bw.WriteByte('\n')
+				}
+				if m.Cell(x, y) == 1 {
+					bw.WriteByte('1')
+				} else {
+					bw.WriteByte('0')
+				}
+			}
+			bw.WriteByte('\n')
+		}
+		return bw.Flush()
+	}
+
+	fmt.Fprintf(bw, "P4\n%d %d\n", width, height)
+	packed := make([]byte, (width+7)/8)
+	for y := 0; y < height; y++ {
+		for i := range packed {
+			packed[i] = 0
+		}
+		for x := 0; x < width; x++ {
+			if m.Cell(x, y) == 1 {
+				packed[x/8] |= 1 << uint(7-x%8)
+			}
+		}
+		bw.Write(packed)
+	}
+	return bw.Flush()
+}
+
+// EncodePGM writes the matrix as a plain (`P2`) or raw (`P5`) PGM image. The
+// values of a matrix of values are rounded and they have to be between 0 and
+// 65535, the maximum value is the highest value. The pixels of other matrixes
+// are the levels, the maximum value is the last level. The ignored cells are
+// `0`
+func EncodePGM(w io.Writer, m *Matrix, plain bool) error {
+	maxValue := m.Levels() - 1
+	pixel := func(x, y int) int {
+		if m.Cell(x, y) == Ignored {
+			return 0
+		}
+		return int(math.Round(m.value(x, y)))
+	}
+	if m.values != nil {
+		lo, hi := m.valueRange()
+		if lo < 0 || hi > 65535 {
+			return fmt.Errorf("only a matrix of values between 0 and 65535 can be encoded as PGM")
+		}
+		maxValue = int(math.Round(hi))
+		if maxValue < 1 {
+			maxValue = 1
+		}
+	}
+	if maxValue > 65535 {
+		return fmt.Errorf("only a matrix with up to 65536 levels can be encoded as PGM")
+	}
+
+	bw := bufio.NewWriter(w)
+	width, height := m.Size()
+	if plain {
+		fmt.Fprintf(bw, "P2\n%d %d\n%d\n", width, height, maxValue)
+		for y := 0; y < height; y++ {
+			line := 0
+			for x := 0; x < width; x++ {
+				v := strconv.Itoa(pixel(x, y))
+				if line != 0 && line+1+len(v) > maxPlainLine {
+					bw.WriteByte('\n')
+					line = 0
+				}
+				if line != 0 {
+					bw.WriteByte(' ')
+					line++
+				}
+				bw.WriteString(v)
+				line += len(v)
+			}
+			bw.WriteByte('\n')
+		}
+		return bw.Flush()
+	}
+
+	fmt.Fprintf(bw, "P5\n%d %d\n%d\n", width, height, maxValue)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := pixel(x, y)
+			if maxValue > 255 {
+				bw.WriteByte(byte(v >> 8))
+			}
+			bw.WriteByte(byte(v))
+		}
+	}
+	return bw.Flush()
+}
+
+// matchesContent returns the cells of the source size with the percentage,
+// from 1 to 100, of the best match covering every cell, or 0 if no match
+// covers it
+func (f *Finder2D) matchesContent() ([][]int, error) {
+	if f.Source == nil {
+		return nil, fmt.Errorf("not set source matrix")
+	}
+	maxX, maxY := f.Source.Size()
+	bounds := image.Rect(0, 0, maxX, maxY)
+	content := make([][]int, maxY)
+	for y := range content {
+		content[y] = make([]int, maxX)
+	}
+	for _, m := range f.Matches {
+		p := int(math.Round(math.Max(1, math.Min(100, m.Percentage))))
+		for _, box := range f.matchBoxes(m) {
+			box = box.Intersect(bounds)
+			for y := box.Min.Y; y < box.Max.Y; y++ {
+				for x := box.Min.X; x < box.Max.X; x++ {
+					if p > content[y][x] {
+						content[y][x] = p
+					}
+				}
+			}
+		}
+	}
+	return content, nil
+}
+
+// EncodeMatchesPBM writes the area of the matches as a raw PBM image of the
+// source size, the cells of any match are black pixels
+func (f *Finder2D) EncodeMatchesPBM(w io.Writer) error {
+	content, err := f.matchesContent()
+	if err != nil {
+		return err
+	}
+	for _, row := range content {
+		for x, v := range row {
+			if v != 0 {
+				row[x] = 1
+			}
+		}
+	}
+	m := &Matrix{}
+	m.setContent(content, f.Source.maxX, f.Source.maxY, 2)
+	return EncodePBM(w, m, false)
+}
+
+// EncodeMatchesPGM writes the area of the matches as a raw PGM image of the
+// source size, the gray of every cell is the percentage of the best match
+// covering it, from black, no match, to white, 100%
+func (f *Finder2D) EncodeMatchesPGM(w io.Writer) error {
+	content, err := f.matchesContent()
+	if err != nil {
+		return err
+	}
+	m := &Matrix{}
+	m.setContent(content, f.Source.maxX, f.Source.maxY, 101)
+	return EncodePGM(w, m, false)
+}
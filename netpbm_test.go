@@ -0,0 +1,178 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeNetpbm(t *testing.T) {
+	tests := []struct {
+		name       string
+		image      string
+		want       [][]int
+		wantValues [][]float64
+		wantErr    bool
+	}{
+		{"plain PBM", "P1\n# a comment\n3 2\n1 0 1\n0 1 0\n", [][]int{{1, 0, 1}, {0, 1, 0}}, nil, false},
+		{"plain PBM without spaces", "P1 3 2 101010", [][]int{{1, 0, 1}, {0, 1, 0}}, nil, false},
+		{"raw PBM", "P4\n10 2\n\xa5\x40\x00\xc0", [][]int{{1, 0, 1, 0, 0, 1, 0, 1, 0, 1}, {0, 0, 0, 0, 0, 0, 0, 0, 1, 1}}, nil, false},
+		{"plain PGM", "P2 3 1 # size\n255\n0 128 255\n", nil, [][]float64{{0, 128, 255}}, false},
+		{"raw PGM", "P5 2 2 255\n\x00\x10\x20\xff", nil, [][]float64{{0, 16}, {32, 255}}, false},
+		{"raw PGM 16 bits", "P5 2 1 1000\n\x00\x10\x03\xe8", nil, [][]float64{{16, 1000}}, false},
+		{"unknown magic number", "P3 1 1 255\n1 2 3\n", nil, nil, true},
+		{"invalid PBM pixel", "P1 2 1 12", nil, nil, true},
+		{"short raster", "P4 8 2\n\xff", nil, nil, true},
+		{"pixel higher than maximum", "P2 1 1 10 11", nil, nil, true},
+		{"invalid maximum value", "P2 1 1 0 0", nil, nil, true},
+		{"huge width", "P4\n99999999999999 1\n", nil, nil, true},
+		{"huge size", "P1 100000 100000\n", nil, nil, true},
+		{"overflow size", "P5 4611686018427387904 4 255\n", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := DecodeNetpbm(strings.NewReader(tt.image))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeNetpbm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantValues != nil {
+				if !reflect.DeepEqual(m.Values(), tt.wantValues) {
+					t.Errorf("DecodeNetpbm() values = %v, want %v", m.Values(), tt.wantValues)
+				}
+				return
+			}
+			if !reflect.DeepEqual(m.Content(), tt.want) || !m.binary() {
+				t.Errorf("DecodeNetpbm() content = %v, want %v", m.Content(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeNetpbm(t *testing.T) {
+	binary := newTestMatrix(t, [][]int{{1, 0, 1, 0, 0, 1, 0, 1, 0, 1}, {0, 0, 0, 0, 0, 0, 0, 0, 1, Ignored}})
+	values, _ := NewValuesMatrix([][]float64{{0, 16}, {32, 300}})
+	tests := []struct {
+		name    string
+		encode  func(b *bytes.Buffer) error
+		want    string
+		wantErr bool
+	}{
+		{"plain PBM", func(b *bytes.Buffer) error { return EncodePBM(b, binary, true) }, "P1\n10 2\n1010010101\n0000000010\n", false},
+		{"raw PBM", func(b *bytes.Buffer) error { return EncodePBM(b, binary, false) }, "P4\n10 2\n\xa5\x40\x00\x80", false},
+		{"PBM of values", func(b *bytes.Buffer) error { return EncodePBM(b, values, true) }, "", true},
+		{"plain PGM", func(b *bytes.Buffer) error { return EncodePGM(b, values, true) }, "P2\n2 2\n300\n0 16\n32 300\n", false},
+		{"raw PGM", func(b *bytes.Buffer) error { return EncodePGM(b, values, false) }, "P5\n2 2\n300\n\x00\x00\x00\x10\x00\x20\x01\x2c", false},
+		{"PGM of binary", func(b *bytes.Buffer) error { return EncodePGM(b, binary, true) }, "P2\n10 2\n1\n1 0 1 0 0 1 0 1 0 1\n0 0 0 0 0 0 0 0 1 0\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.encode(&b); (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := b.String(); !tt.wantErr && got != tt.want {
+				t.Errorf("Encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder2D_EncodeMatches_netpbm(t *testing.T) {
+	f := New(DefaultOne, DefaultZero, 50, 1)
+	f.Matches = []Match{
+		{X: 1, Y: 0, Percentage: 87.5, Width: 2, Height: 2},
+		{X: 2, Y: 1, Percentage: 100, Width: 3, Height: 1},
+	}
+	for _, format := range []string{"pbm", "pgm"} {
+		if err := f.Encode(&bytes.Buffer{}, format); err == nil {
+			t.Errorf("Finder2D.Encode(%q) without source error = nil, want an error", format)
+		}
+	}
+
+	f.Source = newTestMatrix(t, [][]int{make([]int, 10), make([]int, 10)})
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"pbm", "P4\n10 2\n\x60\x00\x78\x00"},
+		{"pgm", "P5\n10 2\n100\n\x00\x58\x58\x00\x00\x00\x00\x00\x00\x00\x00\x58\x64\x64\x64\x00\x00\x00\x00\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := f.Encode(&b, tt.format); err != nil {
+				t.Fatalf("Finder2D.Encode() error = %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Finder2D.Encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Load_netpbm(t *testing.T) {
+	want := loadTestFinder(t, 70, 1)
+	if err := want.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+
+	// the test matrixes as raw and plain PBM images
+	var source, target bytes.Buffer
+	if err := EncodePBM(&source, want.Source, false); err != nil {
+		t.Fatalf("EncodePBM() error = %v", err)
+	}
+	if err := EncodePBM(&target, want.Target, true); err != nil {
+		t.Fatalf("EncodePBM() error = %v", err)
+	}
+	if !IsNetpbm(bufio.NewReader(bytes.NewReader(target.Bytes()))) {
+		t.Fatalf("IsNetpbm() = false, want true")
+	}
+
+	f := New(DefaultOne, DefaultZero, 70, 1)
+	if err := f.LoadSource(&source); err != nil {
+		t.Fatalf("Finder2D.LoadSource() error = %v", err)
+	}
+	if err := f.LoadTarget(&target); err != nil {
+		t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+	}
+	if !f.Source.equal(want.Source) || !f.Target.equal(want.Target) {
+		t.Fatalf("Finder2D loaded matrixes are different to the text matrixes")
+	}
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if !reflect.DeepEqual(f.Matches, want.Matches) {
+		t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want.Matches)
+	}
+
+	text, err := os.Open("test_data/perfect_cat_image.txt")
+	if err != nil {
+		t.Fatalf("failed to open the target matrix. %s", err)
+	}
+	defer text.Close()
+	if IsNetpbm(bufio.NewReader(text)) {
+		t.Errorf("IsNetpbm() of a text matrix = true, want false")
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultIoUThreshold is the default maximum intersection over union of the
+// areas of two matches to be considered different images by the NMS reduction
+const DefaultIoUThreshold = 0.5
+
+// Reduction is the method used to reduce the candidate matches of the same
+// image to the best one
+type Reduction int
+
+// The reduction methods
+const (
+	// ReduceDelta groups the matches with coordinates that differ in at most
+	// `Delta`, transitively, and keeps the best match of every group
+	ReduceDelta Reduction = iota
+	// ReduceNMS is the non-maximum suppression, it keeps the best matches and
+	// removes the matches overlapping them with an intersection over union of
+	// their areas higher than `IoUThreshold`
+	ReduceNMS
+)
+
+// Reductions is the list of all the reduction methods
+var Reductions = []Reduction{ReduceDelta, ReduceNMS}
+
+var reductionNames = []string{"delta", "nms"}
+
+func (r Reduction) String() string {
+	if r < 0 || int(r) >= len(reductionNames) {
+		return fmt.Sprintf("Reduction(%d)", int(r))
+	}
+	return reductionNames[r]
+}
+
+// ParseReduction returns the reduction method with the given name, an empty
+// name is the default method `ReduceDelta`
+func ParseReduction(name string) (Reduction, error) {
+	if len(name) == 0 {
+		return ReduceDelta, nil
+	}
+	for i, n := range reductionNames {
+		if n == name {
+			return Reduction(i), nil
+		}
+	}
+	return ReduceDelta, fmt.Errorf("unknown reduction %q. Available reductions are: %s", name, strings.Join(reductionNames, ", "))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (r Reduction) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (r *Reduction) UnmarshalText(text []byte) error {
+	v, err := ParseReduction(string(text))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// iou returns the intersection over union of the areas of both matches on the
+// torus
+func iou(m, m1 Match, t torus) float64 {
+	i := t.intersection(m, m1)
+	u := m.Width*m.Height + m1.Width*m1.Height - i
+	if u == 0 {
+		return 0
+	}
+	return float64(i) / float64(u)
+}
+
+// suppressMatches is the non-maximum suppression of the matches. The matches
+// are taken from the best to the worst, the higher percentage and on a tie the
+// larger area first, and a match is kept if its intersection over union with
+// every kept match of the same target is not higher than the threshold. The
+// kept matches are returned in scan order. On a torus the areas of the matches
+// wrap around its borders
+func suppressMatches(matches []Match, threshold float64, t torus) []Match {
+	sorted := append([]Match{}, matches...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Percentage != sorted[j].Percentage {
+			return sorted[i].Percentage > sorted[j].Percentage
+		}
+		return sorted[i].Width*sorted[i].Height > sorted[j].Width*sorted[j].Height
+	})
+
+	kept := []Match{}
+	for _, m := range sorted {
+		suppressed := false
+		for _, k := range kept {
+			if m.Target == k.Target && iou(m, k, t) > threshold {
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			kept = append(kept, m)
+		}
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].Y != kept[j].Y {
+			return kept[i].Y < kept[j].Y
+		}
+		return kept[i].X < kept[j].X
+	})
+	return kept
+}
@@ -0,0 +1,113 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_iou(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Match
+		m1   Match
+		want float64
+		t    torus
+	}{
+		{"same", Match{X: 2, Y: 3, Width: 4, Height: 4}, Match{X: 2, Y: 3, Width: 4, Height: 4}, 1, torus{}},
+		{"half width", Match{X: 0, Y: 0, Width: 4, Height: 2}, Match{X: 2, Y: 0, Width: 4, Height: 2}, 1.0 / 3, torus{}},
+		{"inside", Match{X: 0, Y: 0, Width: 4, Height: 4}, Match{X: 1, Y: 1, Width: 2, Height: 2}, 0.25, torus{}},
+		{"apart", Match{X: 0, Y: 0, Width: 4, Height: 4}, Match{X: 4, Y: 4, Width: 4, Height: 4}, 0, torus{}},
+		{"empty", Match{}, Match{}, 0, torus{}},
+		{"across the seam", Match{X: 0, Y: 0, Width: 4, Height: 2}, Match{X: 8, Y: 0, Width: 4, Height: 2}, 0, torus{}},
+		{"across the seam wrapped", Match{X: 0, Y: 0, Width: 4, Height: 2}, Match{X: 8, Y: 0, Width: 4, Height: 2}, 1.0 / 3, torus{10, 4}},
+		{"across the corner wrapped", Match{X: 0, Y: 0, Width: 4, Height: 4}, Match{X: 8, Y: 8, Width: 4, Height: 4}, 1.0 / 7, torus{10, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := iou(tt.m, tt.m1, tt.t); got != tt.want {
+				t.Errorf("iou() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_suppressMatches(t *testing.T) {
+	// a chain of candidates between two images, the delta grouping merges them
+	match := func(x int, p float64) Match {
+		return Match{X: x, Y: 0, Percentage: p, Width: 8, Height: 5}
+	}
+	chain := []Match{match(0, 100), match(1, 70), match(2, 60), match(3, 55), match(4, 60), match(5, 70), match(6, 98)}
+	if got := reduceMatches(chain, 1, torus{}); len(got) != 1 {
+		t.Fatalf("reduceMatches() = %v, want 1 match", got)
+	}
+	// an image across the right border of a wrapped source
+	seam := []Match{match(0, 75), match(13, 100)}
+	if got := reduceMatches(seam, 1, torus{}); len(got) != 2 {
+		t.Fatalf("reduceMatches() = %v, want 2 matches", got)
+	}
+	if got := reduceMatches(seam, 1, torus{14, 5}); !reflect.DeepEqual(got, []Match{match(13, 100)}) {
+		t.Fatalf("reduceMatches() = %v, want %v", got, []Match{match(13, 100)})
+	}
+
+	tests := []struct {
+		name      string
+		matches   []Match
+		threshold float64
+		t         torus
+		want      []Match
+	}{
+		{"empty", []Match{}, 0.5, torus{}, []Match{}},
+		{"chain", chain, 0.3, torus{}, []Match{match(0, 100), match(6, 98)}},
+		{"chain high threshold", chain, 0.5, torus{}, []Match{match(0, 100), match(3, 55), match(6, 98)}},
+		{"other target", []Match{match(0, 90), {X: 1, Percentage: 95, Width: 8, Height: 5, Target: "dog"}}, 0.3, torus{},
+			[]Match{match(0, 90), {X: 1, Percentage: 95, Width: 8, Height: 5, Target: "dog"}}},
+		{"larger area on a tie", []Match{match(2, 90), {X: 0, Percentage: 90, Width: 16, Height: 10, Scale: 2}}, 0.1, torus{},
+			[]Match{{X: 0, Percentage: 90, Width: 16, Height: 10, Scale: 2}}},
+		{"across the seam", []Match{match(0, 75), match(13, 100)}, 0.3, torus{}, []Match{match(0, 75), match(13, 100)}},
+		{"across the seam wrapped", []Match{match(0, 75), match(13, 100)}, 0.3, torus{14, 5}, []Match{match(13, 100)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suppressMatches(tt.matches, tt.threshold, tt.t); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suppressMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Search_nms(t *testing.T) {
+	want := loadTestFinder(t, 70, 1)
+	if err := want.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+
+	f := loadTestFinder(t, 70, 1)
+	f.Reduction = ReduceNMS
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if !reflect.DeepEqual(f.Matches, want.Matches) {
+		t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want.Matches)
+	}
+
+	f.IoUThreshold = 1.5
+	if err := f.Search(); err == nil {
+		t.Errorf("Finder2D.Search() with IoU threshold 1.5 expected an error")
+	}
+}
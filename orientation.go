@@ -0,0 +1,156 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Orientation is one of the eight transformations of a matrix by rotations of
+// 90 degrees clockwise and horizontal flips (mirror)
+type Orientation int
+
+// The eight orientations of a matrix. The flipped orientations are flipped
+// horizontally before being rotated
+const (
+	Rotate0 Orientation = iota
+	Rotate90
+	Rotate180
+	Rotate270
+	Flip
+	FlipRotate90
+	FlipRotate180
+	FlipRotate270
+)
+
+// Orientations is the list of all the orientations
+var Orientations = []Orientation{Rotate0, Rotate90, Rotate180, Rotate270, Flip, FlipRotate90, FlipRotate180, FlipRotate270}
+
+var orientationNames = []string{"r0", "r90", "r180", "r270", "flip", "flip-r90", "flip-r180", "flip-r270"}
+
+func (o Orientation) String() string {
+	if o < 0 || int(o) >= len(orientationNames) {
+		return fmt.Sprintf("Orientation(%d)", int(o))
+	}
+	return orientationNames[o]
+}
+
+// ParseOrientation returns the orientation with the given name
+func ParseOrientation(name string) (Orientation, error) {
+	for i, n := range orientationNames {
+		if n == name {
+			return Orientation(i), nil
+		}
+	}
+	return Rotate0, fmt.Errorf("unknown orientation %q", name)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (o Orientation) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (o *Orientation) UnmarshalText(text []byte) error {
+	v, err := ParseOrientation(string(text))
+	if err != nil {
+		return err
+	}
+	*o = v
+	return nil
+}
+
+// Flipped returns true if the orientation is horizontally flipped
+func (o Orientation) Flipped() bool {
+	return o >= Flip
+}
+
+// Transposed returns true if the orientation swaps the width and height
+func (o Orientation) Transposed() bool {
+	return o%2 == 1
+}
+
+// apply returns the coordinates of the cell (x,y) of a matrix of size (w,h)
+// once the matrix is transformed
+func (o Orientation) apply(x, y, w, h int) (int, int) {
+	if o.Flipped() {
+		x = w - 1 - x
+	}
+	for i := 0; i < int(o%4); i++ {
+		x, y, w, h = h-1-y, x, h, w
+	}
+	return x, y
+}
+
+// Transform returns a new matrix with this matrix in the given orientation
+func (m *Matrix) Transform(o Orientation) *Matrix {
+	w, h := m.Size()
+	if w+h == 0 {
+		return &Matrix{}
+	}
+	tw, th := w, h
+	if o.Transposed() {
+		tw, th = h, w
+	}
+	content := make([][]int, th)
+	for y := range content {
+		content[y] = make([]int, tw)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			tx, ty := o.apply(x, y, w, h)
+			content[ty][tx] = m.Cell(x, y)
+		}
+	}
+
+	t := &Matrix{}
+	t.setContent(content, tw, th, m.levels)
+	if m.values != nil {
+		values := make([][]float64, th)
+		for y := range values {
+			values[y] = make([]float64, tw)
+		}
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				tx, ty := o.apply(x, y, w, h)
+				values[ty][tx] = m.values[y][x]
+			}
+		}
+		t.setValues(values, m.lo, m.hi)
+	}
+	if m.weights != nil {
+		weights := make([][]float64, th)
+		for y := range weights {
+			weights[y] = make([]float64, tw)
+		}
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				tx, ty := o.apply(x, y, w, h)
+				weights[ty][tx] = m.weights[y][x]
+			}
+		}
+		t.SetWeights(weights)
+	}
+	return t
+}
+
+// equal returns true if both matrixes have the same size, levels, content,
+// values and weights
+func (m *Matrix) equal(m1 *Matrix) bool {
+	return m.maxX == m1.maxX && m.maxY == m1.maxY && m.Levels() == m1.Levels() && reflect.DeepEqual(m.Content(), m1.Content()) && m.equalValues(m1) && reflect.DeepEqual(m.weights, m1.weights)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMatrix_Transform(t *testing.T) {
+	m := [][]int{
+		{1, 0, 0},
+		{1, 1, 0},
+	}
+	tests := []struct {
+		o    Orientation
+		want [][]int
+	}{
+		{Rotate0, [][]int{{1, 0, 0}, {1, 1, 0}}},
+		{Rotate90, [][]int{{1, 1}, {1, 0}, {0, 0}}},
+		{Rotate180, [][]int{{0, 1, 1}, {0, 0, 1}}},
+		{Rotate270, [][]int{{0, 0}, {0, 1}, {1, 1}}},
+		{Flip, [][]int{{0, 0, 1}, {0, 1, 1}}},
+		{FlipRotate90, [][]int{{0, 0}, {1, 0}, {1, 1}}},
+		{FlipRotate180, [][]int{{1, 1, 0}, {1, 0, 0}}},
+		{FlipRotate270, [][]int{{1, 1}, {0, 1}, {0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.o.String(), func(t *testing.T) {
+			got := newTestMatrix(t, m).Transform(tt.o)
+			if want := newTestMatrix(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("Matrix.Transform() = %v, want %v", got.Content(), want.Content())
+			}
+		})
+	}
+}
+
+func TestOrientation_JSON(t *testing.T) {
+	for _, o := range Orientations {
+		t.Run(o.String(), func(t *testing.T) {
+			b, err := json.Marshal(Match{Orientation: o})
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var m Match
+			if err := json.Unmarshal(b, &m); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if m.Orientation != o {
+				t.Errorf("json.Unmarshal() orientation = %v, want %v", m.Orientation, o)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Search_anyOrientation(t *testing.T) {
+	target := [][]int{
+		{1, 1, 1, 1, 1, 1},
+		{1, 0, 0, 0, 0, 0},
+		{1, 1, 1, 0, 0, 0},
+		{1, 0, 0, 0, 0, 1},
+	}
+	for _, o := range Orientations {
+		t.Run(o.String(), func(t *testing.T) {
+			rotated := newTestMatrix(t, target).Transform(o)
+			content := make([][]int, 20)
+			for y := range content {
+				content[y] = make([]int, 25)
+			}
+			w, h := rotated.Size()
+			for y := 0; y < h; y++ {
+				copy(content[y+7][11:], rotated.Content()[y])
+			}
+
+			f := New(DefaultOne, DefaultZero, 100, 1)
+			f.Source = newTestMatrix(t, content)
+			f.Target = newTestMatrix(t, target)
+			f.AnyOrientation = true
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			want := []Match{{X: 11, Y: 7, Percentage: 100, Orientation: o}}
+			if !reflect.DeepEqual(f.Matches, want) {
+				t.Fatalf("Finder2D.Search() = %v, want %v", f.Matches, want)
+			}
+			if gotW, gotH := f.MatchSize(f.Matches[0]); gotW != w || gotH != h {
+				t.Errorf("Finder2D.MatchSize() = (%d,%d), want (%d,%d)", gotW, gotH, w, h)
+			}
+			if !f.IsInMatchArea(11+w-1, 7+h-1) || f.IsInMatchArea(11+w, 7) || f.IsInMatchArea(11, 7+h) {
+				t.Errorf("Finder2D.IsInMatchArea() does not match the area (%d,%d) %dx%d", 11, 7, w, h)
+			}
+		})
+	}
+}
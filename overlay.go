@@ -0,0 +1,242 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+)
+
+// OverlayCellSize is the size in pixels of every cell in the overlay images
+const OverlayCellSize = 4
+
+// OverlayFormats are the image formats of the overlay
+var OverlayFormats = []string{"png", "svg"}
+
+var (
+	overlayBox     = color.RGBA{0xe0, 0x20, 0x20, 0xff} // Red
+	overlayLabel   = color.RGBA{0xff, 0xff, 0xff, 0xff} // White
+	overlayIgnored = color.RGBA{0xc0, 0xc0, 0xe0, 0xff} // Light Blue Gray
+)
+
+// labelScale is the size in pixels of every dot of the label glyphs
+const labelScale = 2
+
+// glyphs are the 3x5 dots of the characters of the match labels
+var glyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", "..#", "..#"},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'.': {"...", "...", "...", "...", ".#."},
+	'%': {"#.#", "..#", ".#.", "#..", "#.#"},
+	' ': {"...", "...", "...", "...", "..."},
+}
+
+// EncodeOverlay writes the overlay of the matches in the given format, `png`
+// or `svg`. See Overlay and EncodeOverlaySVG
+func (f *Finder2D) EncodeOverlay(w io.Writer, format string) error {
+	switch format {
+	case "png":
+		return f.EncodeOverlayPNG(w)
+	case "svg":
+		return f.EncodeOverlaySVG(w)
+	}
+	return fmt.Errorf("unknown overlay format %q. Available formats are: %s", format, strings.Join(OverlayFormats, ", "))
+}
+
+// EncodeOverlayPNG writes the overlay of the matches as a PNG image, see Overlay
+func (f *Finder2D) EncodeOverlayPNG(w io.Writer) error {
+	if f.Source == nil {
+		return fmt.Errorf("the source matrix is not loaded")
+	}
+	return png.Encode(w, f.Overlay())
+}
+
+// Overlay returns an image of the source matrix with the outline of the box of
+// every match, labeled with the match index and percentage. Every cell is a
+// square of `OverlayCellSize` pixels, the levels are drawn as ink, from white
+// for the zeros to black for the ones or the highest level, but the numeric
+// values are drawn as brightness, from black for the lowest value. The ignored
+// cells are light blue gray
+func (f *Finder2D) Overlay() *image.RGBA {
+	if f.Source == nil {
+		return image.NewRGBA(image.Rect(0, 0, 0, 0))
+	}
+	w, h := f.Source.Size()
+	img := image.NewRGBA(image.Rect(0, 0, w*OverlayCellSize, h*OverlayCellSize))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			fillRect(img, cellsRect(x, y, 1, 1), f.cellColor(f.Source.Cell(x, y)))
+		}
+	}
+
+	for i, m := range f.Matches {
+		boxes := f.matchBoxes(m)
+		for _, b := range boxes {
+			box := cellsRect(b.Min.X, b.Min.Y, b.Dx(), b.Dy())
+			// a 2 pixels outline inside the box
+			for d := 0; d < 2; d++ {
+				r := box.Inset(d)
+				fillRect(img, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+1), overlayBox)
+				fillRect(img, image.Rect(r.Min.X, r.Max.Y-1, r.Max.X, r.Max.Y), overlayBox)
+				fillRect(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+1, r.Max.Y), overlayBox)
+				fillRect(img, image.Rect(r.Max.X-1, r.Min.Y, r.Max.X, r.Max.Y), overlayBox)
+			}
+		}
+		// the label of a match overhanging the source is inside the image
+		box := cellsRect(boxes[0].Min.X, boxes[0].Min.Y, boxes[0].Dx(), boxes[0].Dy())
+		drawLabel(img, box.Intersect(img.Bounds()).Min, matchLabel(i, m))
+	}
+	return img
+}
+
+// cellColor returns the color of a cell of the source in the overlay
+func (f *Finder2D) cellColor(v int) color.RGBA {
+	if v == Ignored {
+		return overlayIgnored
+	}
+	last := f.Source.Levels() - 1
+	gray := uint8(255 - v*255/last)
+	if f.Source.values != nil {
+		gray = 255 - gray
+	}
+	return color.RGBA{gray, gray, gray, 0xff}
+}
+
+// cellsRect returns the rectangle in pixels of the given cells
+func cellsRect(x, y, w, h int) image.Rectangle {
+	return image.Rect(x*OverlayCellSize, y*OverlayCellSize, (x+w)*OverlayCellSize, (y+h)*OverlayCellSize)
+}
+
+// matchLabel returns the label of the match with the given index
+func matchLabel(i int, m Match) string {
+	return fmt.Sprintf("%d %.1f%%", i, m.Percentage)
+}
+
+// fillRect fills the rectangle of the image with the given color, clipped to
+// the image bounds
+func fillRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	r = r.Intersect(img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+// drawLabel draws the text in white over a box color background with the top
+// left corner at the given point
+func drawLabel(img *image.RGBA, p image.Point, text string) {
+	dot := labelScale
+	width := (4*len(text) + 1) * dot
+	fillRect(img, image.Rect(p.X, p.Y, p.X+width, p.Y+7*dot), overlayBox)
+	for i, c := range text {
+		g := glyphs[c]
+		for gy, row := range g {
+			for gx, d := range row {
+				if d != '#' {
+					continue
+				}
+				x, y := p.X+(1+4*i+gx)*dot, p.Y+(1+gy)*dot
+				fillRect(img, image.Rect(x, y, x+dot, y+dot), overlayLabel)
+			}
+		}
+	}
+}
+
+// EncodeOverlaySVG writes the overlay of the matches as a SVG image, with the
+// same cells, boxes and labels of Overlay. The cells of the same color are
+// drawn as a single path, so the image is a vector image of the size in cells
+// of the source scaled by `OverlayCellSize`
+func (f *Finder2D) EncodeOverlaySVG(w io.Writer) error {
+	if f.Source == nil {
+		return fmt.Errorf("the source matrix is not loaded")
+	}
+	mw, mh := f.Source.Size()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" shape-rendering=\"crispEdges\">\n", mw*OverlayCellSize, mh*OverlayCellSize, mw, mh)
+	f.writeSVGCells(bw)
+
+	if len(f.Matches) != 0 {
+		fmt.Fprintf(bw, "<g fill=\"none\" stroke=\"%s\" stroke-width=\"0.5\">\n", hexColor(overlayBox))
+		for _, m := range f.Matches {
+			for _, b := range f.matchBoxes(m) {
+				fmt.Fprintf(bw, "<rect x=\"%g\" y=\"%g\" width=\"%g\" height=\"%g\"/>\n", float64(b.Min.X)+0.25, float64(b.Min.Y)+0.25, float64(b.Dx())-0.5, float64(b.Dy())-0.5)
+			}
+		}
+		fmt.Fprintln(bw, "</g>")
+		fmt.Fprintf(bw, "<g font-family=\"monospace\" font-size=\"3\" fill=\"%s\" stroke=\"%s\" stroke-width=\"0.5\" paint-order=\"stroke\">\n", hexColor(overlayLabel), hexColor(overlayBox))
+		for i, m := range f.Matches {
+			x, y := math.Max(float64(m.X), 0), math.Max(float64(m.Y), 0)
+			fmt.Fprintf(bw, "<text x=\"%g\" y=\"%g\">%s</text>\n", x+0.75, y+3, matchLabel(i, m))
+		}
+		fmt.Fprintln(bw, "</g>")
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+// writeSVGCells writes the cells of the source in SVG, in the units of the
+// cells. The background is the color of the zeros and the cells of every other
+// color are a single path of runs in every row
+func (f *Finder2D) writeSVGCells(w io.Writer) {
+	mw, mh := f.Source.Size()
+	background := f.cellColor(0)
+	fmt.Fprintf(w, "<rect width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", mw, mh, hexColor(background))
+	paths := map[color.RGBA]*bytes.Buffer{}
+	var colors []color.RGBA
+	for y, row := range f.Source.Content() {
+		for x := 0; x < len(row); {
+			n := 1
+			for x+n < len(row) && row[x+n] == row[x] {
+				n++
+			}
+			if c := f.cellColor(row[x]); c != background {
+				p, ok := paths[c]
+				if !ok {
+					p = &bytes.Buffer{}
+					paths[c] = p
+					colors = append(colors, c)
+				}
+				fmt.Fprintf(p, "M%d %dh%dv1h-%dz", x, y, n, n)
+			}
+			x += n
+		}
+	}
+	for _, c := range colors {
+		fmt.Fprintf(w, "<path fill=\"%s\" d=\"%s\"/>\n", hexColor(c), paths[c].String())
+	}
+}
+
+// hexColor returns the color in the hexadecimal notation of SVG
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestFinder2D_Overlay(t *testing.T) {
+	f := New(DefaultOne, DefaultZero, 50, 1)
+	f.Source = newTestMatrix(t, [][]int{
+		{1, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, Ignored},
+	})
+	f.Matches = []Match{{X: 5, Y: 1, Percentage: 87.5, Width: 4, Height: 6}}
+
+	img := f.Overlay()
+	if got, want := img.Bounds().Size(), cellsRect(0, 0, 10, 8).Size(); got != want {
+		t.Fatalf("Finder2D.Overlay() size = %v, want %v", got, want)
+	}
+	tests := []struct {
+		name string
+		x, y int
+		want color.RGBA
+	}{
+		{"one", 1, 1, color.RGBA{0, 0, 0, 0xff}},
+		{"zero", 5, 1, color.RGBA{0xff, 0xff, 0xff, 0xff}},
+		{"ignored", 9*OverlayCellSize + 1, 7*OverlayCellSize + 1, overlayIgnored},
+		{"box", 9*OverlayCellSize - 1, 7*OverlayCellSize - 2, overlayBox},
+		{"inside the box", 9*OverlayCellSize - 3, 7*OverlayCellSize - 3, color.RGBA{0xff, 0xff, 0xff, 0xff}},
+		{"label", 5 * OverlayCellSize, 1 * OverlayCellSize, overlayBox},
+		{"label glyph", 5*OverlayCellSize + labelScale, 1*OverlayCellSize + labelScale, overlayLabel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := img.RGBAAt(tt.x, tt.y); got != tt.want {
+				t.Errorf("Finder2D.Overlay() at (%d,%d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+
+	// the PNG overlay is the same image
+	decoded, err := png.Decode(strings.NewReader(f.Stringf("png")))
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if got := color.RGBAModel.Convert(decoded.At(1, 1)); got != img.At(1, 1) {
+		t.Errorf("Finder2D.Stringf(png) at (1,1) = %v, want %v", got, img.At(1, 1))
+	}
+}
+
+func TestFinder2D_EncodeOverlay(t *testing.T) {
+	f := New(DefaultOne, DefaultZero, 50, 1)
+	f.Source = newTestMatrix(t, [][]int{
+		{1, 1, 0, 1},
+		{0, 0, 0, Ignored},
+	})
+	f.Matches = []Match{{X: 1, Y: 0, Percentage: 75, Width: 2, Height: 2}}
+
+	tests := []struct {
+		name     string
+		format   string
+		contains []string
+		wantErr  bool
+	}{
+		{"png", "png", []string{"\x89PNG"}, false},
+		{"svg", "svg", []string{
+			`<svg xmlns="http://www.w3.org/2000/svg" width="16" height="8" viewBox="0 0 4 2"`,
+			`<rect width="4" height="2" fill="#ffffff"/>`,
+			`<path fill="#000000" d="M0 0h2v1h-2zM3 0h1v1h-1z"/>`,
+			`<path fill="#c0c0e0" d="M3 1h1v1h-1z"/>`,
+			`<rect x="1.25" y="0.25" width="1.5" height="1.5"/>`,
+			`<text x="1.75" y="3">0 75.0%</text>`,
+		}, false},
+		{"unknown format", "gif", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := f.EncodeOverlay(&b, tt.format); (err != nil) != tt.wantErr {
+				t.Fatalf("Finder2D.EncodeOverlay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(b.String(), s) {
+					t.Errorf("Finder2D.EncodeOverlay() = %q, want it to contain %q", b.String(), s)
+				}
+			}
+		})
+	}
+
+	if err := New(DefaultOne, DefaultZero, 50, 1).EncodeOverlay(&bytes.Buffer{}, "svg"); err == nil {
+		t.Errorf("Finder2D.EncodeOverlay() without source error = nil, want an error")
+	}
+}
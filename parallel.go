@@ -0,0 +1,242 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultWorkers is the default number of workers searching in parallel, one
+// worker means the search is sequential
+const DefaultWorkers = 1
+
+// bandsPerWorker is the number of bands of rows assigned to every worker, more
+// bands balance better the work between the workers
+const bandsPerWorker = 4
+
+// progressBands is the minimum number of bands the source is split into when
+// the progress is reported
+const progressBands = 100
+
+// ProgressFunc is called during the search with the number of rows of the
+// source scanned and the total number of rows to scan. It's never called
+// concurrently
+type ProgressFunc func(scanned, total int)
+
+// scanRows returns the number of rows of the source where the top-left corner
+// of the target may be
+func scanRows(source, target *Matrix) int {
+	_, maxY := source.Size()
+	_, height := target.Size()
+	if rows := maxY - height + 1; rows > 0 {
+		return rows
+	}
+	return 0
+}
+
+// band is a range of rows of the source where the target top-left corner may
+// be, from the row `y0` to the row `y1` (not included)
+type band struct {
+	y0, y1 int
+}
+
+// splitBands splits the `rows` rows in `n` bands of similar height
+func splitBands(rows, n int) []band {
+	if n > rows {
+		n = rows
+	}
+	bands := make([]band, 0, n)
+	for i := 0; i < n; i++ {
+		bands = append(bands, band{
+			y0: rows * i / n,
+			y1: rows * (i + 1) / n,
+		})
+	}
+	return bands
+}
+
+// bandsFor returns the bands of the `rows` rows to search with the given
+// number of workers, at least `progressBands` if the progress is reported
+func bandsFor(rows, workers int, progress bool) []band {
+	// every worker searches at least a band of one row
+	if workers > rows {
+		workers = rows
+	}
+	n := workers * bandsPerWorker
+	if progress && n < progressBands {
+		n = progressBands
+	}
+	return splitBands(rows, n)
+}
+
+// searchBands splits the source in row bands and search the target in every
+// band with the given searcher using a pool of workers. The matches of every
+// band are merged in the bands order, so they are in the same order as a
+// sequential search. The progress, if given, is reported every time a band is
+// scanned
+func searchBands(ctx context.Context, s Searcher, source, target *Matrix, params SearchParams, workers int, progress ProgressFunc) ([]Match, error) {
+	maxX, _ := source.Size()
+	_, height := target.Size()
+	rows := scanRows(source, target)
+	if workers < 1 {
+		workers = 1
+	}
+	if (workers == 1 && progress == nil) || rows <= 1 || height == 0 {
+		matches, err := s.Search(ctx, source, target, params)
+		if err == nil && progress != nil {
+			progress(rows, rows)
+		}
+		return matches, err
+	}
+
+	bands := bandsFor(rows, workers, progress != nil)
+	results := make([][]Match, len(bands))
+	err := runBands(ctx, bands, workers, rows, progress, func(ctx context.Context, i int) error {
+		b := bands[i]
+		sample := source.Sample(0, b.y0, maxX, b.y1-b.y0+height-1)
+		matches, err := s.Search(ctx, sample, target, params)
+		if err != nil {
+			return err
+		}
+		for j := range matches {
+			matches[j].Y += b.y0
+		}
+		results[i] = matches
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []Match{}
+	for _, ms := range results {
+		matches = append(matches, ms...)
+	}
+	return matches, nil
+}
+
+// searchTargetsBands is like searchBands but searching all the targets in a
+// single pass of the source with the given searcher, every band is scanned
+// once for all of them. It returns the matches of every target
+func searchTargetsBands(ctx context.Context, s targetsSearcher, source *Matrix, targets []scanTarget, params SearchParams, workers int, progress ProgressFunc) ([][]Match, error) {
+	var rows int
+	for _, t := range targets {
+		if t.rows > rows {
+			rows = t.rows
+		}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if (workers == 1 && progress == nil) || rows <= 1 {
+		matches, err := s.searchTargets(ctx, source, targets, params, 0, rows)
+		if err == nil && progress != nil {
+			progress(rows, rows)
+		}
+		return matches, err
+	}
+
+	bands := bandsFor(rows, workers, progress != nil)
+	results := make([][][]Match, len(bands))
+	err := runBands(ctx, bands, workers, rows, progress, func(ctx context.Context, i int) error {
+		matches, err := s.searchTargets(ctx, source, targets, params, bands[i].y0, bands[i].y1)
+		results[i] = matches
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([][]Match, len(targets))
+	for t := range matches {
+		matches[t] = []Match{}
+		for _, ms := range results {
+			matches[t] = append(matches[t], ms[t]...)
+		}
+	}
+	return matches, nil
+}
+
+// runBands calls search with the index of every band using a pool of workers,
+// until every band is searched or any search fails. The progress, if given,
+// is reported every time a band is searched. It returns the error of the band
+// that failed, or the context error if the search is cancelled
+func runBands(ctx context.Context, bands []band, workers, rows int, progress ProgressFunc, search func(ctx context.Context, i int) error) error {
+	if workers > len(bands) {
+		workers = len(bands)
+	}
+	errs := make([]error, len(bands))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var scanned int
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := search(ctx, i); err != nil {
+					errs[i] = err
+					cancel()
+					continue
+				}
+
+				if progress != nil {
+					mu.Lock()
+					scanned += bands[i].y1 - bands[i].y0
+					progress(scanned, rows)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	func() {
+		defer close(jobs)
+		for i := range bands {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	// when the search is cancelled return the band error that caused it, if any
+	if err := ctx.Err(); err != nil {
+		for _, e := range errs {
+			if e != nil && e != context.Canceled {
+				return e
+			}
+		}
+		return err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -17,41 +17,153 @@ limitations under the License.
 package cli
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/johandry/finder2d"
 )
 
-// Execute executes the CLI mode, loading the matrixes and printing the matches
-func Execute(sourceFileName, targetFileName, zero, one string, percentage float64, delta int, format string) error {
-	switch format {
-	case "", "text", "matrix", "json":
-	default:
-		return fmt.Errorf("unknown output format %q. Available options are: 'json', 'text' or 'matrix'", format)
+// Options are the parameters of the CLI mode
+type Options struct {
+	SourceFileName string
+	TargetFiles    []string
+	Zero           string
+	One            string
+	Ignore         string
+	Alphabet       string
+	Comparison     string
+	Values         bool
+	Tolerance      float64
+	Threshold      string
+	Percentage     float64
+	Delta          int
+	Strategy       string
+	Workers        int
+	AnyOrientation bool
+	Scales         string
+	Metric         string
+	WeightsFile    string
+	Reduction      string
+	IoUThreshold   float64
+	TopK           int
+	Significance   bool
+	MaxPValue      float64
+	Border         bool
+	MinVisible     float64
+	Wrap           bool
+	Progress       bool
+	Format         string
+	Columns        string
+	OutFileName    string
+	Heatmap        string
+	Suggest        bool
+}
+
+// Execute executes the CLI mode, loading the matrixes and printing the matches,
+// or the suggested percentage and delta if `Suggest` is set
+func Execute(opts Options) error {
+	encoder, err := getEncoder(opts.Format, opts.Columns)
+	if err != nil {
+		return err
 	}
 
-	if len(sourceFileName) == 0 {
-		return fmt.Errorf("source file is required")
+	if err := checkHeatmapFormat(opts.Heatmap); err != nil {
+		return err
 	}
 
-	// Open files
-	sourceFile, err := os.Open(sourceFileName)
+	if _, err := finder2d.GetSearcher(opts.Strategy); err != nil {
+		return err
+	}
+
+	scales, err := finder2d.ParseScales(opts.Scales)
+	if err != nil {
+		return err
+	}
+
+	metric, err := finder2d.ParseMetric(opts.Metric)
 	if err != nil {
-		return fmt.Errorf("fail to open the frame file %q. %s", sourceFileName, err)
+		return err
 	}
-	targetFile, err := os.Open(targetFileName)
+
+	reduction, err := finder2d.ParseReduction(opts.Reduction)
+	if err != nil {
+		return err
+	}
+
+	comparison, err := finder2d.ParseComparison(opts.Comparison)
+	if err != nil {
+		return err
+	}
+
+	threshold, err := finder2d.ParseThreshold(opts.Threshold)
+	if err != nil {
+		return err
+	}
+
+	one, zero, ignore, err := parseCells(opts.One, opts.Zero, opts.Ignore)
 	if err != nil {
-		return fmt.Errorf("fail to open the image file %q. %s", targetFileName, err)
+		return err
 	}
 
+	if len(opts.SourceFileName) == 0 {
+		return fmt.Errorf("source file is required")
+	}
+
+	if opts.Border && opts.Wrap {
+		return fmt.Errorf("the border and wrap modes cannot be used together")
+	}
+
+	// Open files
+	sourceFile, err := os.Open(opts.SourceFileName)
+	if err != nil {
+		return fmt.Errorf("fail to open the frame file %q. %s", opts.SourceFileName, err)
+	}
+	defer sourceFile.Close()
+
 	// Load matrixes from files
-	f := finder2d.New([]byte(one)[0], []byte(zero)[0], percentage, delta)
+	options := []finder2d.Option{finder2d.WithWorkers(opts.Workers), finder2d.WithIgnore(ignore)}
+	if opts.Values {
+		options = append(options, finder2d.WithValues())
+	}
+	f := finder2d.New(one, zero, opts.Percentage, opts.Delta, options...)
+	if err := f.SetAlphabet(opts.Alphabet); err != nil {
+		return err
+	}
+	f.Strategy = opts.Strategy
+	f.AnyOrientation = opts.AnyOrientation
+	f.Scales = scales
+	f.Metric = metric
+	f.Comparison = comparison
+	f.Tolerance = opts.Tolerance
+	f.Threshold = threshold
+	f.Reduction = reduction
+	if opts.IoUThreshold != 0 {
+		f.IoUThreshold = opts.IoUThreshold
+	}
+	f.TopK = opts.TopK
+	f.Significance = opts.Significance
+	f.MaxPValue = opts.MaxPValue
+	f.Border = opts.Border
+	f.Wrap = opts.Wrap
+	if opts.MinVisible != 0 {
+		f.MinVisible = opts.MinVisible
+	}
 	if err := f.LoadSource(sourceFile); err != nil {
-		return fmt.Errorf("fail to load the source file %q. %s", sourceFileName, err)
+		return fmt.Errorf("fail to load the source file %q. %s", opts.SourceFileName, err)
+	}
+	if err := loadTargets(f, opts.TargetFiles); err != nil {
+		return err
 	}
-	if err := f.LoadTarget(targetFile); err != nil {
-		return fmt.Errorf("fail to load the target file %q. %s", sourceFileName, err)
+	if len(opts.WeightsFile) != 0 {
+		if err := loadWeights(f, opts.WeightsFile); err != nil {
+			return err
+		}
 	}
 
 	// DEBUG:
@@ -61,11 +173,193 @@ func Execute(sourceFileName, targetFileName, zero, one string, percentage float6
 	// fmt.Printf("Target (%dx%d): \n%s\n", x, y, f.Target)
 	// fmt.Println("Finding matches ...")
 
-	if err := f.SearchSimple(); err != nil {
+	if opts.Suggest {
+		return outputSuggestion(f, opts.Format, opts.OutFileName)
+	}
+
+	if len(opts.Heatmap) != 0 {
+		return outputHeatmap(f, opts.Heatmap, opts.OutFileName)
+	}
+
+	searchOpts := &finder2d.SearchOptions{}
+	if opts.Progress {
+		searchOpts.Progress = progressBar(os.Stderr)
+	}
+
+	if err := f.SearchContext(context.Background(), searchOpts); err != nil {
 		return fmt.Errorf("failed to search the target matrix. %s", err)
 	}
 
-	fmt.Println(f.Stringf(format))
+	return output(f, encoder, opts.Format, opts.OutFileName)
+}
 
+// getEncoder returns the encoder of the output format, with the given comma
+// separated list of columns if any
+func getEncoder(format, columnNames string) (finder2d.Encoder, error) {
+	encoder, err := finder2d.GetEncoder(format)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := finder2d.ParseColumns(columnNames)
+	if err != nil || len(columns) == 0 {
+		return encoder, err
+	}
+	ce, ok := encoder.(finder2d.ColumnsEncoder)
+	if !ok {
+		return nil, fmt.Errorf("the output format %q has no columns", format)
+	}
+	return ce.WithColumns(columns), nil
+}
+
+// output writes the matches with the encoder to the output file, or prints
+// them if the file is not set
+func output(f *finder2d.Finder2D, encoder finder2d.Encoder, format, fileName string) error {
+	var b bytes.Buffer
+	if err := encoder.Encode(&b, f); err != nil {
+		return fmt.Errorf("failed to encode the matches. %s", err)
+	}
+	// the PNG and Netpbm images are binary and the lines of some formats end
+	// with a new line, they are printed as they are
+	switch format {
+	case "png", "pbm", "pgm", "csv", "tsv", "yaml", "ndjson":
+		return write(b.Bytes(), fileName)
+	}
+	if len(fileName) == 0 {
+		b.WriteString("\n")
+	}
+	return write(b.Bytes(), fileName)
+}
+
+// checkHeatmapFormat returns an error if the heatmap format is unknown, an
+// empty format is no heatmap
+func checkHeatmapFormat(format string) error {
+	if len(format) == 0 {
+		return nil
+	}
+	for _, hf := range finder2d.HeatmapFormats {
+		if format == hf {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown heatmap format %q. Available formats are: %s", format, strings.Join(finder2d.HeatmapFormats, ", "))
+}
+
+// outputHeatmap writes the heatmap of the target in the given format to the
+// output file, or prints it if the file is not set
+func outputHeatmap(f *finder2d.Finder2D, format, fileName string) error {
+	h, err := f.Heatmap(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to compute the heatmap. %s", err)
+	}
+	var b bytes.Buffer
+	if err := h.Encode(&b, format); err != nil {
+		return fmt.Errorf("failed to encode the heatmap. %s", err)
+	}
+	return write(b.Bytes(), fileName)
+}
+
+// outputSuggestion writes the suggested percentage and delta, with the
+// histogram of the scores, as JSON or text to the output file, or prints it if
+// the file is not set
+func outputSuggestion(f *finder2d.Finder2D, format, fileName string) error {
+	s, err := f.Suggest(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to suggest the percentage and delta. %s", err)
+	}
+	var out []byte
+	switch format {
+	case "json":
+		if out, err = json.Marshal(s); err != nil {
+			return fmt.Errorf("failed to encode the suggestion. %s", err)
+		}
+		out = append(out, '\n')
+	case "text":
+		out = []byte(s.String())
+	default:
+		return fmt.Errorf("unknown suggestion format %q. Available formats are: json, text", format)
+	}
+	return write(out, fileName)
+}
+
+// write writes the output to the file, or prints it if the file is not set
+func write(out []byte, fileName string) error {
+	if len(fileName) == 0 {
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+	if err := ioutil.WriteFile(fileName, out, 0644); err != nil {
+		return fmt.Errorf("fail to write the output file %q. %s", fileName, err)
+	}
+	return nil
+}
+
+// parseCells returns the characters of the one, zero and ignored cells
+func parseCells(oneValue, zeroValue, ignoreValue string) (one, zero, ignore rune, err error) {
+	if one, err = finder2d.ParseCell(oneValue); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid on character. %s", err)
+	}
+	if zero, err = finder2d.ParseCell(zeroValue); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid off character. %s", err)
+	}
+	if ignore, err = finder2d.ParseCell(ignoreValue); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid ignore character. %s", err)
+	}
+	return one, zero, ignore, nil
+}
+
+// loadTargets loads the target files, a single target is loaded as the finder
+// target and multiple targets are added to the library named by the file name
+// without extension, so the matches are tagged with the target name. Two files
+// with the same name are an error, one target would replace the other
+func loadTargets(f *finder2d.Finder2D, fileNames []string) error {
+	if len(fileNames) == 0 {
+		return fmt.Errorf("target file is required")
+	}
+	files := map[string]string{}
+	for _, fileName := range fileNames {
+		name := targetName(fileName)
+		if other, ok := files[name]; ok {
+			return fmt.Errorf("the target files %q and %q have the same name %q", other, fileName, name)
+		}
+		files[name] = fileName
+	}
+	for _, fileName := range fileNames {
+		targetFile, err := os.Open(fileName)
+		if err != nil {
+			return fmt.Errorf("fail to open the image file %q. %s", fileName, err)
+		}
+		if len(fileNames) == 1 {
+			err = f.LoadTarget(targetFile)
+		} else {
+			err = f.AddTarget(targetName(fileName), targetFile)
+		}
+		targetFile.Close()
+		if err != nil {
+			return fmt.Errorf("fail to load the target file %q. %s", fileName, err)
+		}
+	}
+	return nil
+}
+
+// targetName returns the name of the target in a file, the file name without
+// extension
+func targetName(fileName string) string {
+	return strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+}
+
+// loadWeights loads the weights file for the target, it's only allowed with a
+// single target
+func loadWeights(f *finder2d.Finder2D, fileName string) error {
+	if f.Target == nil {
+		return fmt.Errorf("the weights file can only be used with a single target")
+	}
+	weightsFile, err := os.Open(fileName)
+	if err != nil {
+		return fmt.Errorf("fail to open the weights file %q. %s", fileName, err)
+	}
+	defer weightsFile.Close()
+	if err := f.LoadWeights(weightsFile); err != nil {
+		return fmt.Errorf("fail to load the weights file %q. %s", fileName, err)
+	}
 	return nil
 }
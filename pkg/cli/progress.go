@@ -0,0 +1,42 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/johandry/finder2d"
+)
+
+const progressBarWidth = 40
+
+// progressBar returns a progress function that renders a progress bar in the
+// given writer, usually the standard error to not mix it with the matches
+func progressBar(w io.Writer) finder2d.ProgressFunc {
+	return func(scanned, total int) {
+		if total == 0 {
+			return
+		}
+		done := progressBarWidth * scanned / total
+		fmt.Fprintf(w, "\r[%s%s] %3d%% (%d/%d rows)", strings.Repeat("#", done), strings.Repeat(" ", progressBarWidth-done), 100*scanned/total, scanned, total)
+		if scanned == total {
+			fmt.Fprintln(w)
+		}
+	}
+}
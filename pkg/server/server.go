@@ -57,13 +57,13 @@ type Server struct {
 }
 
 // Serve starts serving
-func Serve(port, sourceFileName, zero, one string) error {
+func Serve(port, sourceFileName, zero, one, ignore, alphabet string, values bool, threshold string) error {
 	s := &Server{
 		host: "localhost",
 		port: port,
 	}
 
-	if err := s.newFinder2D(sourceFileName, zero, one); err != nil {
+	if err := s.newFinder2D(sourceFileName, zero, one, ignore, alphabet, values, threshold); err != nil {
 		return err
 	}
 
@@ -78,7 +78,33 @@ func Serve(port, sourceFileName, zero, one string) error {
 	return s.Wait()
 }
 
-func (s *Server) newFinder2D(sourceFileName, zero, one string) error {
+func (s *Server) newFinder2D(sourceFileName, zero, one, ignore, alphabet string, values bool, threshold string) error {
+	oneValue, err := finder2d.ParseCell(one)
+	if err != nil {
+		return fmt.Errorf("invalid on character. %s", err)
+	}
+	zeroValue, err := finder2d.ParseCell(zero)
+	if err != nil {
+		return fmt.Errorf("invalid off character. %s", err)
+	}
+	ignoreValue, err := finder2d.ParseCell(ignore)
+	if err != nil {
+		return fmt.Errorf("invalid ignore character. %s", err)
+	}
+	thresholdValue, err := finder2d.ParseThreshold(threshold)
+	if err != nil {
+		return err
+	}
+	options := []finder2d.Option{finder2d.WithIgnore(ignoreValue)}
+	if values {
+		options = append(options, finder2d.WithValues())
+	}
+	s.finder = finder2d.New(oneValue, zeroValue, 0, 0, options...)
+	if err := s.finder.SetAlphabet(alphabet); err != nil {
+		return err
+	}
+	s.finder.Threshold = thresholdValue
+
 	if len(sourceFileName) == 0 {
 		return nil
 	}
@@ -88,8 +114,6 @@ func (s *Server) newFinder2D(sourceFileName, zero, one string) error {
 		return fmt.Errorf("fail to open the frame file %q. %s", sourceFileName, err)
 	}
 
-	s.finder = finder2d.New([]byte(one)[0], []byte(zero)[0], 0, 0)
-
 	if err := s.finder.LoadSource(sourceFile); err != nil {
 		return fmt.Errorf("fail to load the source file %q. %s", sourceFileName, err)
 	}
@@ -122,9 +146,12 @@ func (s *Server) Start() error {
 
 	gwopts := []grpc.DialOption{grpc.WithInsecure()}
 
+	// the HttpBody responses, like the overlay images, are sent as they are
 	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(
 		runtime.MIMEWildcard,
-		&runtime.JSONPb{OrigName: true, EmitDefaults: true},
+		&runtime.HTTPBodyMarshaler{
+			Marshaler: &runtime.JSONPb{OrigName: true, EmitDefaults: true},
+		},
 	))
 
 	log.Printf("[DEBUG] registering service for HTTP/REST")
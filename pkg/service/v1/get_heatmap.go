@@ -0,0 +1,59 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"log"
+
+	apiv1 "github.com/johandry/finder2d/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetHeatmap implement the API method from the generated protobuf
+func (s *Finder2DService) GetHeatmap(ctx context.Context, req *apiv1.GetHeatmapRequest) (*apiv1.GetHeatmapResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if err := s.checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	heatmap, err := s.finder.Heatmap(ctx)
+	if err != nil {
+		log.Printf("[ERROR] %s", err)
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to compute the heatmap. %s", err)
+	}
+
+	width, height := heatmap.Size()
+	scores := make([]float32, 0, width*height)
+	for _, row := range heatmap.Scores {
+		for _, score := range row {
+			scores = append(scores, float32(score))
+		}
+	}
+
+	log.Printf("[INFO] sending heatmap (%d,%d)", width, height)
+
+	return &apiv1.GetHeatmapResponse{
+		Api:    apiVersion,
+		Width:  int32(width),
+		Height: int32(height),
+		Scores: scores,
+	}, nil
+}
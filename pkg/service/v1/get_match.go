@@ -26,6 +26,9 @@ import (
 
 // GetMatch implement the API method from the generated protobuf
 func (s *Finder2DService) GetMatch(ctx context.Context, req *apiv1.GetMatchRequest) (*apiv1.GetMatchResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	if req.Id < 0 || int(req.Id) >= len(s.finder.Matches) {
 		errMsg := fmt.Sprintf("not found match with id=%d", req.Id)
 		log.Printf("[ERROR] %s", errMsg)
@@ -33,20 +36,19 @@ func (s *Finder2DService) GetMatch(ctx context.Context, req *apiv1.GetMatchReque
 	}
 
 	match := s.finder.Matches[int(req.Id)]
-	m := &apiv1.Match{
-		X:          int32(match.X),
-		Y:          int32(match.Y),
-		Percentage: float32(match.Percentage),
-	}
+	m := s.newMatch(match)
 
-	targetW, targetH := s.finder.Target.Size()
-	matrix := s.finder.Source.Sample(match.X, match.Y, targetW, targetH)
-	z, o := s.finder.Values()
-	content := matrix.Sprintf(string([]byte{z}), string([]byte{o}))
+	matrix := s.finder.MatchArea(match)
+	if matrix == nil {
+		errMsg := fmt.Sprintf("the area of the match with id=%d is out of the source matrix", req.Id)
+		log.Printf("[ERROR] %s", errMsg)
+		return nil, fmt.Errorf(errMsg)
+	}
+	width, height := matrix.Size()
 	matx := &apiv1.Matrix{
-		Width:   int32(targetW),
-		Height:  int32(targetH),
-		Content: content,
+		Width:   int32(width),
+		Height:  int32(height),
+		Content: s.finder.Sprintf(matrix),
 	}
 
 	log.Printf("[INFO] match id=%d requested and returned", req.Id)
@@ -0,0 +1,69 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/johandry/finder2d"
+	apiv1 "github.com/johandry/finder2d/api/v1"
+)
+
+func TestFinder2DService_GetMatch(t *testing.T) {
+	f := finder2d.New('+', ' ', 50, 1)
+	source := "+  +\n ++ \n    \n"
+	if err := f.LoadSource(strings.NewReader(source)); err != nil {
+		t.Fatalf("Finder2D.LoadSource() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		border  bool
+		wrap    bool
+		match   finder2d.Match
+		want    string
+		wantErr bool
+	}{
+		{"inside", false, false, finder2d.Match{X: 1, Y: 0, Width: 2, Height: 2}, "  \n++\n", false},
+		{"border left top", true, false, finder2d.Match{X: -1, Y: -1, Width: 2, Height: 2}, "??\n?+\n", false},
+		{"border right bottom", true, false, finder2d.Match{X: 3, Y: 2, Width: 2, Height: 2}, " ?\n??\n", false},
+		{"wrap", false, true, finder2d.Match{X: 3, Y: 2, Width: 2, Height: 2}, "  \n++\n", false},
+		{"out of the source", false, false, finder2d.Match{X: -1, Y: 0, Width: 2, Height: 2}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f.Border, f.Wrap = tt.border, tt.wrap
+			f.Matches = []finder2d.Match{tt.match}
+			got, err := New(f).GetMatch(context.Background(), &apiv1.GetMatchRequest{Api: apiVersion, Id: 0})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Finder2DService.GetMatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Matrix.Content != tt.want || got.Matrix.Width != 2 || got.Matrix.Height != 2 {
+				t.Errorf("Finder2DService.GetMatch() matrix = %dx%d %q, want 2x2 %q", got.Matrix.Width, got.Matrix.Height, got.Matrix.Content, tt.want)
+			}
+		})
+	}
+
+	if _, err := New(f).GetMatch(context.Background(), &apiv1.GetMatchRequest{Api: apiVersion, Id: 1}); err == nil {
+		t.Errorf("Finder2DService.GetMatch() of an unknown match error = nil, want an error")
+	}
+}
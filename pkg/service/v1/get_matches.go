@@ -25,15 +25,13 @@ import (
 
 // GetMatches implement the API method from the generated protobuf
 func (s *Finder2DService) GetMatches(ctx context.Context, req *apiv1.GetMatchesRequest) (*apiv1.GetMatchesResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	ms := []*apiv1.Match{}
 
 	for _, match := range s.finder.Matches {
-		m := &apiv1.Match{
-			X:          int32(match.X),
-			Y:          int32(match.Y),
-			Percentage: float32(match.Percentage),
-		}
-		ms = append(ms, m)
+		ms = append(ms, s.newMatch(match))
 	}
 
 	log.Printf("[INFO] list of matches requested, returned %d matches", len(ms))
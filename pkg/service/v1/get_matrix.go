@@ -28,6 +28,9 @@ import (
 
 // GetMatrix implement the API method from the generated protobuf
 func (s *Finder2DService) GetMatrix(ctx context.Context, req *apiv1.GetMatrixRequest) (*apiv1.GetMatrixResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	if err := s.checkAPIVersion(req.Api); err != nil {
 		return nil, err
 	}
@@ -46,8 +49,7 @@ func (s *Finder2DService) GetMatrix(ctx context.Context, req *apiv1.GetMatrixReq
 		return nil, fmt.Errorf("matrix not found, load the matrix")
 	}
 
-	z, o := s.finder.Values()
-	content := m.Sprintf(string([]byte{z}), string([]byte{o}))
+	content := s.finder.Sprintf(m)
 	w, h := m.Size()
 
 	log.Printf("[INFO] sending %s matrix (%d,%d)", strings.ToLower(req.Name.String()), w, h)
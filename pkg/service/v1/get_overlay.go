@@ -0,0 +1,71 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+
+	"github.com/johandry/finder2d"
+	apiv1 "github.com/johandry/finder2d/api/v1"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// overlayContentTypes are the content types of the overlay image formats
+var overlayContentTypes = map[string]string{
+	"png": "image/png",
+	"svg": "image/svg+xml",
+}
+
+// GetOverlay implement the API method from the generated protobuf
+func (s *Finder2DService) GetOverlay(ctx context.Context, req *apiv1.GetOverlayRequest) (*httpbody.HttpBody, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if err := s.checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	format := strings.ToLower(req.Format)
+	if len(format) == 0 {
+		format = "png"
+	}
+	contentType, ok := overlayContentTypes[format]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown overlay format %q. Available formats are: %s", req.Format, strings.Join(finder2d.OverlayFormats, ", "))
+	}
+	if s.finder.Source == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "source matrix not found, load the matrix")
+	}
+
+	var b bytes.Buffer
+	if err := s.finder.EncodeOverlay(&b, format); err != nil {
+		log.Printf("[ERROR] %s", err)
+		return nil, status.Errorf(codes.Internal, "failed to encode the overlay. %s", err)
+	}
+
+	log.Printf("[INFO] sending %s overlay of %d matches", format, len(s.finder.Matches))
+
+	return &httpbody.HttpBody{
+		ContentType: contentType,
+		Data:        b.Bytes(),
+	}, nil
+}
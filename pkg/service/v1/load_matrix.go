@@ -17,29 +17,58 @@ limitations under the License.
 package v1
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"log"
 	"strings"
 
+	"github.com/johandry/finder2d"
 	apiv1 "github.com/johandry/finder2d/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // LoadMatrix implement the API method from the generated protobuf
 func (s *Finder2DService) LoadMatrix(ctx context.Context, req *apiv1.LoadMatrixRequest) (*apiv1.LoadMatrixResponse, error) {
-	r := strings.NewReader(req.Matrix.Content)
-	var err error
-	var w, h int
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the alphabet and threshold are set in a copy of the finder, committed
+	// only if the matrix is loaded. Empty values reset them to the defaults,
+	// the binary matrixes and the Otsu's threshold
+	f := *s.finder
+	if err := f.SetAlphabet(req.Alphabet); err != nil {
+		log.Printf("[ERROR] %s", err)
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+	threshold, err := finder2d.ParseThreshold(strings.ToLower(req.Threshold))
+	if err != nil {
+		log.Printf("[ERROR] %s", err)
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
+	}
+	f.Threshold = threshold
+
+	// the data is a PBM, PGM, PNG or GIF image instead of the content text
+	var r io.Reader = strings.NewReader(req.GetMatrix().GetContent())
+	if len(req.Data) != 0 {
+		r = bytes.NewReader(req.Data)
+	}
+	var m *finder2d.Matrix
 	switch req.Name {
 	case apiv1.MatrixName_SOURCE:
-		err = s.finder.LoadSource(r)
-		w, h = s.finder.Source.Size()
+		err = f.LoadSource(r)
+		m = f.Source
 	case apiv1.MatrixName_TARGET:
-		err = s.finder.LoadTarget(r)
-		w, h = s.finder.Target.Size()
+		err = f.LoadTarget(r)
+		m = f.Target
 	}
 	if err != nil {
-		return nil, err
+		log.Printf("[ERROR] %s", err)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to load the %s matrix. %s", strings.ToLower(req.Name.String()), err)
 	}
+	*s.finder = f
+	w, h := m.Size()
 
 	log.Printf("[INFO] %s matrix (%d,%d) loaded", strings.ToLower(req.Name.String()), w, h)
 
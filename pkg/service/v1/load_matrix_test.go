@@ -0,0 +1,82 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/johandry/finder2d"
+	apiv1 "github.com/johandry/finder2d/api/v1"
+)
+
+func TestFinder2DService_LoadMatrix(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *apiv1.LoadMatrixRequest
+		wantErr       bool
+		wantAlphabet  string
+		wantThreshold finder2d.Threshold
+		wantWidth     int
+	}{
+		{"alphabet", &apiv1.LoadMatrixRequest{Alphabet: ".xo", Matrix: &apiv1.Matrix{Content: "x.o\n"}}, false, ".xo", finder2d.ThresholdOtsu, 3},
+		{"reset to binary", &apiv1.LoadMatrixRequest{Matrix: &apiv1.Matrix{Content: "+ \n"}}, false, "", finder2d.ThresholdOtsu, 2},
+		{"threshold", &apiv1.LoadMatrixRequest{Threshold: "gray", Matrix: &apiv1.Matrix{Content: "+ \n"}}, false, "", finder2d.ThresholdGray, 2},
+		{"reset threshold", &apiv1.LoadMatrixRequest{Matrix: &apiv1.Matrix{Content: "+ \n"}}, false, "", finder2d.ThresholdOtsu, 2},
+		{"invalid alphabet", &apiv1.LoadMatrixRequest{Alphabet: "xx", Matrix: &apiv1.Matrix{Content: "x\n"}}, true, ".xo", finder2d.ThresholdGray, 3},
+		{"invalid threshold", &apiv1.LoadMatrixRequest{Alphabet: "ab", Threshold: "none", Matrix: &apiv1.Matrix{Content: "ab\n"}}, true, ".xo", finder2d.ThresholdGray, 3},
+		{"invalid content", &apiv1.LoadMatrixRequest{Alphabet: "ab", Matrix: &apiv1.Matrix{Content: "abc\n"}}, true, ".xo", finder2d.ThresholdGray, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the failed requests start from a finder with an alphabet and a
+			// threshold, which are kept
+			f := finder2d.New('+', ' ', 0, 0)
+			if tt.wantErr {
+				if err := f.SetAlphabet(".xo"); err != nil {
+					t.Fatalf("Finder2D.SetAlphabet() error = %v", err)
+				}
+				f.Threshold = finder2d.ThresholdGray
+				if err := f.LoadSource(strings.NewReader("x.o\n")); err != nil {
+					t.Fatalf("Finder2D.LoadSource() error = %v", err)
+				}
+			} else {
+				if err := f.SetAlphabet("abc"); err != nil {
+					t.Fatalf("Finder2D.SetAlphabet() error = %v", err)
+				}
+				f.Threshold = 128
+			}
+
+			tt.req.Api = apiVersion
+			tt.req.Name = apiv1.MatrixName_SOURCE
+			_, err := New(f).LoadMatrix(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Finder2DService.LoadMatrix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := f.Alphabet(); got != tt.wantAlphabet {
+				t.Errorf("Finder2DService.LoadMatrix() alphabet = %q, want %q", got, tt.wantAlphabet)
+			}
+			if f.Threshold != tt.wantThreshold {
+				t.Errorf("Finder2DService.LoadMatrix() threshold = %v, want %v", f.Threshold, tt.wantThreshold)
+			}
+			if w, _ := f.Source.Size(); w != tt.wantWidth {
+				t.Errorf("Finder2DService.LoadMatrix() source width = %d, want %d", w, tt.wantWidth)
+			}
+		})
+	}
+}
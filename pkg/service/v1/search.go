@@ -21,39 +21,243 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/johandry/finder2d"
 	apiv1 "github.com/johandry/finder2d/api/v1"
 )
 
+// maxWorkers is the maximum number of workers searching in parallel that a
+// search request may ask for
+const maxWorkers = 64
+
+// maxScales is the maximum number of scale factors of the target that a search
+// request may ask for, and maxScale the highest scale factor
+const (
+	maxScales = 16
+	maxScale  = 10
+)
+
 // Search implement the API method from the generated protobuf
 func (s *Finder2DService) Search(ctx context.Context, req *apiv1.SearchRequest) (*apiv1.SearchResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := s.searchFinder(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.SearchContext(ctx, nil); err != nil {
+		errMsg := fmt.Sprintf("failed to search the target matrix. %s", err)
+		log.Printf("[ERROR] %s", errMsg)
+		return nil, fmt.Errorf(errMsg)
+	}
+	*s.finder = *f
+
+	n := s.logSearch()
+
+	return &apiv1.SearchResponse{
+		Api:          apiVersion,
+		TotalMatches: int32(n),
+	}, nil
+}
+
+// SearchStream implement the API method from the generated protobuf
+func (s *Finder2DService) SearchStream(req *apiv1.SearchRequest, stream apiv1.Finder2D_SearchStreamServer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := s.searchFinder(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var errSend error
+	opts := &finder2d.SearchOptions{
+		Progress: func(scanned, total int) {
+			if errSend != nil {
+				return
+			}
+			errSend = stream.Send(&apiv1.SearchProgress{
+				Api:         apiVersion,
+				ScannedRows: int32(scanned),
+				TotalRows:   int32(total),
+			})
+			if errSend != nil {
+				cancel()
+			}
+		},
+	}
+
+	if err := f.SearchContext(ctx, opts); err != nil {
+		if errSend != nil {
+			err = errSend
+		}
+		errMsg := fmt.Sprintf("failed to search the target matrix. %s", err)
+		log.Printf("[ERROR] %s", errMsg)
+		return fmt.Errorf(errMsg)
+	}
+	*s.finder = *f
+
+	n := s.logSearch()
+
+	return stream.Send(&apiv1.SearchProgress{
+		Api:          apiVersion,
+		Done:         true,
+		TotalMatches: int32(n),
+	})
+}
+
+// searchFinder returns a copy of the finder with the search parameters of the
+// request, or an error if any of them is invalid. The search is done with the
+// copy, and the finder is replaced by it only if the search succeeds, so a
+// failed or cancelled search does not modify the finder. It has to be called
+// with the service locked
+func (s *Finder2DService) searchFinder(req *apiv1.SearchRequest) (*finder2d.Finder2D, error) {
+	if err := s.checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
 	if s.finder.Source == nil {
 		errMsg := "the Finder2D does not have a frame or source matrix, load the source matrix first"
 		log.Printf("[ERROR] %s", errMsg)
 		return nil, fmt.Errorf(errMsg)
 	}
-	if s.finder.Target == nil {
-		errMsg := "the Finder2D does not have an image or target matrix, load the target matrix first"
+	if s.finder.Target == nil && len(s.finder.Targets()) == 0 {
+		errMsg := "the Finder2D does not have an image or target matrix, load the target matrix or add a target first"
 		log.Printf("[ERROR] %s", errMsg)
 		return nil, fmt.Errorf(errMsg)
 	}
+	if req.Workers > maxWorkers {
+		errMsg := fmt.Sprintf("the number of workers (%d) cannot be more than %d", req.Workers, maxWorkers)
+		log.Printf("[ERROR] %s", errMsg)
+		return nil, fmt.Errorf(errMsg)
+	}
+	if len(req.Scales) > maxScales {
+		errMsg := fmt.Sprintf("the number of scales (%d) cannot be more than %d", len(req.Scales), maxScales)
+		log.Printf("[ERROR] %s", errMsg)
+		return nil, fmt.Errorf(errMsg)
+	}
+	for _, scale := range req.Scales {
+		if scale > maxScale {
+			errMsg := fmt.Sprintf("the scale factor %v cannot be higher than %d", scale, maxScale)
+			log.Printf("[ERROR] %s", errMsg)
+			return nil, fmt.Errorf(errMsg)
+		}
+	}
+	if req.Border && req.Wrap {
+		errMsg := "the border and wrap modes cannot be used together"
+		log.Printf("[ERROR] %s", errMsg)
+		return nil, fmt.Errorf(errMsg)
+	}
+
+	f := *s.finder
 	if req.Percentage != 0 {
-		s.finder.Percentage = float64(req.Percentage)
+		f.Percentage = float64(req.Percentage)
 	}
 	if req.Delta != 0 {
-		s.finder.Delta = int(req.Delta)
+		f.Delta = int(req.Delta)
 	}
-
-	if err := s.finder.SearchSimple(); err != nil {
-		errMsg := fmt.Sprintf("failed to search the target matrix. %s", err)
+	if len(req.Strategy) != 0 {
+		if _, err := finder2d.GetSearcher(req.Strategy); err != nil {
+			log.Printf("[ERROR] %s", err)
+			return nil, err
+		}
+		f.Strategy = req.Strategy
+	}
+	if req.Workers != 0 {
+		f.Workers = int(req.Workers)
+	}
+	if len(req.Metric) != 0 {
+		metric, err := finder2d.ParseMetric(req.Metric)
+		if err != nil {
+			log.Printf("[ERROR] %s", err)
+			return nil, err
+		}
+		f.Metric = metric
+	}
+	if len(req.Comparison) != 0 {
+		comparison, err := finder2d.ParseComparison(req.Comparison)
+		if err != nil {
+			log.Printf("[ERROR] %s", err)
+			return nil, err
+		}
+		f.Comparison = comparison
+	}
+	if req.Tolerance != 0 {
+		f.Tolerance = float64(req.Tolerance)
+	}
+	if len(req.Reduction) != 0 {
+		reduction, err := finder2d.ParseReduction(req.Reduction)
+		if err != nil {
+			log.Printf("[ERROR] %s", err)
+			return nil, err
+		}
+		f.Reduction = reduction
+	}
+	if req.IouThreshold != 0 {
+		f.IoUThreshold = float64(req.IouThreshold)
+	}
+	f.TopK = int(req.TopK)
+	f.Significance = req.Significance
+	f.MaxPValue = req.MaxPValue
+	f.Border = req.Border
+	f.Wrap = req.Wrap
+	if req.MinVisible != 0 {
+		f.MinVisible = float64(req.MinVisible)
+	}
+	f.AnyOrientation = req.AnyOrientation
+	f.Scales = make([]float64, 0, len(req.Scales))
+	for _, scale := range req.Scales {
+		f.Scales = append(f.Scales, float64(scale))
+	}
+	if err := f.Validate(); err != nil {
+		log.Printf("[ERROR] %s", err)
+		return nil, err
+	}
+	if f.Target != nil {
+		target, err := weightedTarget(f.Target, req.Weights)
+		if err != nil {
+			log.Printf("[ERROR] %s", err)
+			return nil, err
+		}
+		f.Target = target
+	} else if len(req.Weights) != 0 {
+		errMsg := "the weights are for the target matrix, load the target matrix first"
 		log.Printf("[ERROR] %s", errMsg)
 		return nil, fmt.Errorf(errMsg)
 	}
+	return &f, nil
+}
 
+func (s *Finder2DService) logSearch() int {
 	n := len(s.finder.Matches)
-	log.Printf("[INFO] searched target matrix in source matrix with matching percentage %f and blurry delta %d, found %d matches", s.finder.Percentage, s.finder.Delta, n)
+	log.Printf("[INFO] searched target matrix in source matrix with strategy %q (%d workers), matching percentage %f and blurry delta %d, found %d matches", s.finder.Strategy, s.finder.Workers, s.finder.Percentage, s.finder.Delta, n)
+	return n
+}
 
-	return &apiv1.SearchResponse{
-		Api:          apiVersion,
-		TotalMatches: int32(n),
-	}, nil
+// weightedTarget returns a copy of the target with the weights of the cells,
+// given row by row. No weights removes the target weights
+func weightedTarget(target *finder2d.Matrix, weights []float32) (*finder2d.Matrix, error) {
+	wt := *target
+	if len(weights) == 0 {
+		return &wt, wt.SetWeights(nil)
+	}
+
+	w, h := wt.Size()
+	if len(weights) != w*h {
+		return nil, fmt.Errorf("the number of weights (%d) is different to the number of target cells (%d)", len(weights), w*h)
+	}
+	rows := make([][]float64, h)
+	for y := range rows {
+		rows[y] = make([]float64, w)
+		for x := range rows[y] {
+			rows[y][x] = float64(weights[y*w+x])
+		}
+	}
+	if err := wt.SetWeights(rows); err != nil {
+		return nil, err
+	}
+	return &wt, nil
 }
@@ -0,0 +1,164 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/johandry/finder2d"
+	apiv1 "github.com/johandry/finder2d/api/v1"
+)
+
+func TestFinder2DService_Search(t *testing.T) {
+	f := finder2d.New('+', ' ', 50, 1)
+	if err := f.LoadSource(strings.NewReader("+  +\n ++ \n    \n")); err != nil {
+		t.Fatalf("Finder2D.LoadSource() error = %v", err)
+	}
+	if err := f.LoadTarget(strings.NewReader("++\n  \n")); err != nil {
+		t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		req     *apiv1.SearchRequest
+		want    int32
+		wantErr bool
+	}{
+		{"search", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100}, 1, false},
+		{"wrap", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Wrap: true}, 2, false},
+		{"workers", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Workers: 4}, 1, false},
+		{"too many workers", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Workers: maxWorkers + 1}, 0, true},
+		{"border and wrap", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Border: true, Wrap: true}, 0, true},
+		{"scales", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Scales: []float32{1, 2}}, 1, false},
+		{"too many scales", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Scales: make([]float32, maxScales+1)}, 0, true},
+		{"too large scale", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Scales: []float32{1, maxScale + 1}}, 0, true},
+		{"NaN scale", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Scales: []float32{float32(math.NaN())}}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(f).Search(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Finder2DService.Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.TotalMatches != tt.want {
+				t.Errorf("Finder2DService.Search() total matches = %d, want %d", got.TotalMatches, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder2DService_Search_invalid(t *testing.T) {
+	f := finder2d.New('+', ' ', 50, 1)
+	if err := f.LoadSource(strings.NewReader("+  +\n ++ \n    \n")); err != nil {
+		t.Fatalf("Finder2D.LoadSource() error = %v", err)
+	}
+	if err := f.LoadTarget(strings.NewReader("++\n  \n")); err != nil {
+		t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+	}
+
+	// the finder is not modified by a request with an invalid parameter
+	tests := []struct {
+		name string
+		req  *apiv1.SearchRequest
+	}{
+		{"metric", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Delta: 3, Metric: "unknown"}},
+		{"iou threshold", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Delta: 3, IouThreshold: 2}},
+		{"weights", &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Delta: 3, Weights: []float32{1, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(f).Search(context.Background(), tt.req); err == nil {
+				t.Fatalf("Finder2DService.Search() error = nil, want an error")
+			}
+			if f.Percentage != 50 || f.Delta != 1 || f.IoUThreshold != finder2d.DefaultIoUThreshold {
+				t.Errorf("Finder2DService.Search() modified the finder: percentage %v, delta %d, IoU threshold %v", f.Percentage, f.Delta, f.IoUThreshold)
+			}
+		})
+	}
+}
+
+func TestFinder2DService_Search_cancelled(t *testing.T) {
+	f := finder2d.New('+', ' ', 50, 1)
+	if err := f.LoadSource(strings.NewReader("+  +\n ++ \n    \n")); err != nil {
+		t.Fatalf("Finder2D.LoadSource() error = %v", err)
+	}
+	if err := f.LoadTarget(strings.NewReader("++\n  \n")); err != nil {
+		t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+	}
+	s := New(f)
+	if _, err := s.Search(context.Background(), &apiv1.SearchRequest{Api: apiVersion, Percentage: 100}); err != nil {
+		t.Fatalf("Finder2DService.Search() error = %v", err)
+	}
+	matches := f.Matches
+
+	// a cancelled search keeps the finder and the matches of the last search
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := &apiv1.SearchRequest{Api: apiVersion, Percentage: 90, Wrap: true, Weights: []float32{1, 1, 1, 1}}
+	if _, err := s.Search(ctx, req); err == nil {
+		t.Fatalf("Finder2DService.Search() error = nil, want an error")
+	}
+	if f.Percentage != 100 || f.Wrap || f.Target.Weights() != nil || !reflect.DeepEqual(f.Matches, matches) {
+		t.Errorf("Finder2DService.Search() modified the finder: percentage %v, wrap %v, weights %v, matches %v", f.Percentage, f.Wrap, f.Target.Weights(), f.Matches)
+	}
+}
+
+func TestFinder2DService_Search_concurrent(t *testing.T) {
+	f := finder2d.New('+', ' ', 50, 1)
+	if err := f.LoadSource(strings.NewReader("+  +\n ++ \n    \n")); err != nil {
+		t.Fatalf("Finder2D.LoadSource() error = %v", err)
+	}
+	if err := f.LoadTarget(strings.NewReader("++\n  \n")); err != nil {
+		t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+	}
+	s := New(f)
+
+	// every search finds its own matches, run with -race to check the locking
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(wrap bool) {
+			defer wg.Done()
+			got, err := s.Search(context.Background(), &apiv1.SearchRequest{Api: apiVersion, Percentage: 100, Wrap: wrap})
+			if err != nil {
+				t.Errorf("Finder2DService.Search() error = %v", err)
+				return
+			}
+			if want := map[bool]int32{false: 1, true: 2}[wrap]; got.TotalMatches != want {
+				t.Errorf("Finder2DService.Search() with wrap %v total matches = %d, want %d", wrap, got.TotalMatches, want)
+			}
+			if _, err := s.GetMatches(context.Background(), &apiv1.GetMatchesRequest{Api: apiVersion}); err != nil {
+				t.Errorf("Finder2DService.GetMatches() error = %v", err)
+			}
+			if _, err := s.GetHeatmap(context.Background(), &apiv1.GetHeatmapRequest{Api: apiVersion}); err != nil {
+				t.Errorf("Finder2DService.GetHeatmap() error = %v", err)
+			}
+			if _, err := s.Suggest(context.Background(), &apiv1.SuggestRequest{Api: apiVersion}); err != nil {
+				t.Errorf("Finder2DService.Suggest() error = %v", err)
+			}
+		}(i%2 == 0)
+	}
+	wg.Wait()
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"sync"
+
 	"github.com/johandry/finder2d"
 	apiv1 "github.com/johandry/finder2d/api/v1"
 	"google.golang.org/grpc"
@@ -28,6 +30,9 @@ const apiVersion = "v1"
 
 // Finder2DService defines the Finder2D service
 type Finder2DService struct {
+	// mu serializes the requests modifying the finder, a search or a load, with
+	// the requests reading it
+	mu     sync.RWMutex
 	finder *finder2d.Finder2D
 }
 
@@ -43,6 +48,24 @@ func (s *Finder2DService) Register(server *grpc.Server) {
 	apiv1.RegisterFinder2DServer(server, s)
 }
 
+// newMatch returns the API match of the finder match, with the size of the
+// area of the match
+func (s *Finder2DService) newMatch(m finder2d.Match) *apiv1.Match {
+	w, h := s.finder.MatchSize(m)
+	return &apiv1.Match{
+		X:           int32(m.X),
+		Y:           int32(m.Y),
+		Percentage:  float32(m.Percentage),
+		Orientation: m.Orientation.String(),
+		Width:       int32(w),
+		Height:      int32(h),
+		Scale:       float32(m.Scale),
+		Target:      m.Target,
+		PValue:      m.PValue,
+		Visible:     float32(m.Visible),
+	}
+}
+
 func (s *Finder2DService) checkAPIVersion(version string) error {
 	if len(version) != 0 && version != apiVersion {
 		return status.Errorf(codes.Unimplemented, "API version %q is not supported. This services implements API version %q", version, apiVersion)
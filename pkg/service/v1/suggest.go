@@ -0,0 +1,58 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"log"
+
+	apiv1 "github.com/johandry/finder2d/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Suggest implement the API method from the generated protobuf
+func (s *Finder2DService) Suggest(ctx context.Context, req *apiv1.SuggestRequest) (*apiv1.SuggestResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if err := s.checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	suggestion, err := s.finder.Suggest(ctx)
+	if err != nil {
+		log.Printf("[ERROR] %s", err)
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to suggest the percentage and delta. %s", err)
+	}
+
+	histogram := make([]int32, len(suggestion.Histogram))
+	for i, n := range suggestion.Histogram {
+		histogram[i] = int32(n)
+	}
+
+	log.Printf("[INFO] sending suggestion: percentage %g, delta %d", suggestion.Percentage, suggestion.Delta)
+
+	return &apiv1.SuggestResponse{
+		Api:          apiVersion,
+		Percentage:   float32(suggestion.Percentage),
+		Delta:        int32(suggestion.Delta),
+		Images:       int32(suggestion.Images),
+		HistogramLow: float32(suggestion.HistogramLow),
+		Histogram:    histogram,
+	}, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	apiv1 "github.com/johandry/finder2d/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddTarget implement the API method from the generated protobuf
+func (s *Finder2DService) AddTarget(ctx context.Context, req *apiv1.AddTargetRequest) (*apiv1.AddTargetResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+	if req.Matrix == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "the target matrix is required")
+	}
+
+	if err := s.finder.AddTarget(req.Name, strings.NewReader(req.Matrix.Content)); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "fail to load the target %q. %s", req.Name, err)
+	}
+
+	w, h := s.finder.GetTarget(req.Name).Size()
+	log.Printf("[INFO] target %q (%d,%d) added to the library", req.Name, w, h)
+
+	return &apiv1.AddTargetResponse{
+		Api: apiVersion,
+	}, nil
+}
+
+// RemoveTarget implement the API method from the generated protobuf
+func (s *Finder2DService) RemoveTarget(ctx context.Context, req *apiv1.RemoveTargetRequest) (*apiv1.RemoveTargetResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	if err := s.finder.RemoveTarget(req.Name); err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", err)
+	}
+
+	log.Printf("[INFO] target %q removed from the library", req.Name)
+
+	return &apiv1.RemoveTargetResponse{
+		Api: apiVersion,
+	}, nil
+}
+
+// ListTargets implement the API method from the generated protobuf
+func (s *Finder2DService) ListTargets(ctx context.Context, req *apiv1.ListTargetsRequest) (*apiv1.ListTargetsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if err := s.checkAPIVersion(req.Api); err != nil {
+		return nil, err
+	}
+
+	names := s.finder.Targets()
+	log.Printf("[INFO] sending %d targets of the library", len(names))
+
+	targets := make([]*apiv1.Target, 0, len(names))
+	for _, name := range names {
+		w, h := s.finder.GetTarget(name).Size()
+		targets = append(targets, &apiv1.Target{
+			Name:   name,
+			Width:  int32(w),
+			Height: int32(h),
+		})
+	}
+
+	return &apiv1.ListTargetsResponse{
+		Api:     apiVersion,
+		Targets: targets,
+	}, nil
+}
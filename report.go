@@ -0,0 +1,157 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"image"
+	"io"
+)
+
+// reportTemplate is the standalone HTML report, with the styles and scripts
+// inline so it's a single file
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Finder2D Report</title>
+<style>
+body { font-family: sans-serif; margin: 1em 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 0.2em 0.6em; text-align: right; }
+#params th { text-align: left; }
+#matches th { cursor: pointer; background: #eee; user-select: none; }
+#matches th.asc::after { content: " \25B2"; }
+#matches th.desc::after { content: " \25BC"; }
+#matches tbody tr:hover { background: #fde0e0; }
+svg { border: 1px solid #ccc; }
+.match { fill: none; stroke: {{.BoxColor}}; stroke-width: 0.5; }
+.match.active { fill: {{.BoxColor}}; fill-opacity: 0.4; stroke: #ffd020; }
+</style>
+</head>
+<body>
+<h1>Finder2D Report</h1>
+<h2>Search Parameters</h2>
+<table id="params">
+<tr><th>Percentage</th><td>{{.Percentage}}</td></tr>
+<tr><th>Delta</th><td>{{.Delta}}</td></tr>
+<tr><th>Strategy</th><td>{{.Strategy}}</td></tr>
+<tr><th>Metric</th><td>{{.Metric}}</td></tr>
+<tr><th>Reduction</th><td>{{.Reduction}}</td></tr>
+</table>
+<h2>Source ({{.Cols}}x{{.Rows}})</h2>
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Cols}} {{.Rows}}" shape-rendering="crispEdges">
+{{.Cells}}{{range .Matches}}<rect id="match-{{.ID}}" class="match" x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{.Height}}"><title>#{{.ID}} {{.Percentage}}%</title></rect>
+{{$m := .}}{{range $i, $b := .Wrapped}}<rect id="match-{{$m.ID}}-{{$i}}" class="match" x="{{$b.Min.X}}" y="{{$b.Min.Y}}" width="{{$b.Dx}}" height="{{$b.Dy}}"><title>#{{$m.ID}} {{$m.Percentage}}%</title></rect>
+{{end}}{{end}}</svg>
+<h2>Matches ({{len .Matches}})</h2>
+<table id="matches">
+<thead><tr><th>ID</th><th>X</th><th>Y</th><th>Width</th><th>Height</th><th>Percentage</th></tr></thead>
+<tbody>
+{{range .Matches}}<tr data-match="{{.ID}}"><td>{{.ID}}</td><td>{{.X}}</td><td>{{.Y}}</td><td>{{.Width}}</td><td>{{.Height}}</td><td>{{.Percentage}}</td></tr>
+{{end}}</tbody>
+</table>
+<script>
+(function() {
+  var table = document.getElementById("matches");
+  var body = table.tBodies[0];
+  Array.prototype.forEach.call(body.rows, function(row) {
+    var id = "match-" + row.getAttribute("data-match");
+    var areas = [document.getElementById(id)];
+    // the pieces of a match split by the borders of a wrapped source
+    for (var i = 0, area; (area = document.getElementById(id + "-" + i)); i++) {
+      areas.push(area);
+    }
+    row.addEventListener("mouseenter", function() { areas.forEach(function(a) { a.classList.add("active"); }); });
+    row.addEventListener("mouseleave", function() { areas.forEach(function(a) { a.classList.remove("active"); }); });
+  });
+  Array.prototype.forEach.call(table.tHead.rows[0].cells, function(th, col) {
+    th.addEventListener("click", function() {
+      var asc = !th.classList.contains("asc");
+      Array.prototype.forEach.call(th.parentNode.cells, function(c) { c.classList.remove("asc", "desc"); });
+      th.classList.add(asc ? "asc" : "desc");
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function(a, b) {
+        var d = parseFloat(a.cells[col].textContent) - parseFloat(b.cells[col].textContent);
+        return asc ? d : -d;
+      });
+      rows.forEach(function(row) { body.appendChild(row); });
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`))
+
+// reportData are the values of the HTML report
+type reportData struct {
+	Percentage, Delta, Strategy, Metric, Reduction string
+	Cols, Rows, Width, Height                      int
+	BoxColor                                       string
+	Cells                                          template.HTML
+	Matches                                        []reportMatch
+}
+
+// reportMatch is a match in the HTML report. The wrapped boxes are the pieces
+// of the area of a match crossing the borders of a wrapped source
+type reportMatch struct {
+	ID, X, Y, Width, Height int
+	Percentage              string
+	Wrapped                 []image.Rectangle
+}
+
+// EncodeReport writes a standalone HTML report of the search, with the search
+// parameters, the source with the area of every match highlighted, and a table
+// of the matches sortable by any column. Hovering a match in the table
+// highlights its area in the source
+func (f *Finder2D) EncodeReport(w io.Writer) error {
+	if f.Source == nil {
+		return fmt.Errorf("the source matrix is not loaded")
+	}
+	cols, rows := f.Source.Size()
+	var cells bytes.Buffer
+	f.writeSVGCells(&cells)
+	data := reportData{
+		Percentage: fmt.Sprintf("%g", f.Percentage),
+		Delta:      fmt.Sprintf("%d", f.Delta),
+		Strategy:   f.Strategy,
+		Metric:     f.Metric.String(),
+		Reduction:  f.Reduction.String(),
+		Cols:       cols,
+		Rows:       rows,
+		Width:      cols * OverlayCellSize,
+		Height:     rows * OverlayCellSize,
+		BoxColor:   hexColor(overlayBox),
+		Cells:      template.HTML(cells.String()),
+	}
+	for i, m := range f.Matches {
+		mw, mh := f.MatchSize(m)
+		data.Matches = append(data.Matches, reportMatch{
+			ID:         i,
+			X:          m.X,
+			Y:          m.Y,
+			Width:      mw,
+			Height:     mh,
+			Percentage: fmt.Sprintf("%.2f", m.Percentage),
+			Wrapped:    f.matchBoxes(m)[1:],
+		})
+	}
+	return reportTemplate.Execute(w, data)
+}
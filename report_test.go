@@ -0,0 +1,62 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFinder2D_EncodeReport(t *testing.T) {
+	f := New(DefaultOne, DefaultZero, 75, 2)
+	f.Source = newTestMatrix(t, [][]int{
+		{1, 1, 0, 1},
+		{0, 0, 0, Ignored},
+	})
+	f.Matches = []Match{
+		{X: 1, Y: 0, Percentage: 75, Width: 2, Height: 2},
+		{X: 2, Y: 0, Percentage: 87.5, Width: 2, Height: 2},
+	}
+
+	var b bytes.Buffer
+	if err := f.EncodeReport(&b); err != nil {
+		t.Fatalf("Finder2D.EncodeReport() error = %v", err)
+	}
+	report := b.String()
+	for _, want := range []string{
+		`<tr><th>Percentage</th><td>75</td></tr>`,
+		`<tr><th>Delta</th><td>2</td></tr>`,
+		`<tr><th>Strategy</th><td>` + DefaultStrategy + `</td></tr>`,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="16" height="8" viewBox="0 0 4 2"`,
+		`<path fill="#000000" d="M0 0h2v1h-2zM3 0h1v1h-1z"/>`,
+		`<rect id="match-1" class="match" x="2" y="0" width="2" height="2">`,
+		`<tr data-match="0"><td>0</td><td>1</td><td>0</td><td>2</td><td>2</td><td>75.00</td></tr>`,
+		`<tr data-match="1"><td>1</td><td>2</td><td>0</td><td>2</td><td>2</td><td>87.50</td></tr>`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Finder2D.EncodeReport() = %q, want it to contain %q", report, want)
+		}
+	}
+	if got := f.Stringf("html"); got != report {
+		t.Errorf("Finder2D.Stringf(html) is different to the report")
+	}
+
+	if err := New(DefaultOne, DefaultZero, 50, 1).EncodeReport(&bytes.Buffer{}); err == nil {
+		t.Errorf("Finder2D.EncodeReport() without source error = nil, want an error")
+	}
+}
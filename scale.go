@@ -0,0 +1,180 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MinScaleOverlap is the minimum overlap between the areas of two matches of
+// different size to be considered the same image. The overlap is the
+// intersection of the areas over the smaller area
+const MinScaleOverlap = 0.5
+
+// Resize returns a new matrix with the given size. When the matrix is reduced
+// every cell is the majority of the cells not ignored of the area it covers, a
+// tie is the higher level (a one), and it's ignored if all the cells are
+// ignored. When the matrix is enlarged every cell is the nearest cell. The
+// weights and the numeric values, if any, are resized the same way with the
+// mean of the area, the values not ignored
+func (m *Matrix) Resize(w, h int) *Matrix {
+	if w <= 0 || h <= 0 || m.maxX+m.maxY == 0 {
+		return &Matrix{}
+	}
+	content := make([][]int, h)
+	counts := make([]int, m.Levels())
+	var weights, values [][]float64
+	if m.weights != nil {
+		weights = make([][]float64, h)
+	}
+	if m.values != nil {
+		values = make([][]float64, h)
+	}
+	for y := range content {
+		content[y] = make([]int, w)
+		if weights != nil {
+			weights[y] = make([]float64, w)
+		}
+		if values != nil {
+			values[y] = make([]float64, w)
+		}
+		y0, y1 := scaleRange(y, h, m.maxY)
+		for x := range content[y] {
+			x0, x1 := scaleRange(x, w, m.maxX)
+			if weights != nil {
+				var sum float64
+				for yi := y0; yi < y1; yi++ {
+					for xi := x0; xi < x1; xi++ {
+						sum += m.weights[yi][xi]
+					}
+				}
+				weights[y][x] = sum / float64((y1-y0)*(x1-x0))
+			}
+			if values != nil {
+				var sum float64
+				var cells int
+				for yi := y0; yi < y1; yi++ {
+					for xi := x0; xi < x1; xi++ {
+						if v := m.values[yi][xi]; !math.IsNaN(v) {
+							sum += v
+							cells++
+						}
+					}
+				}
+				values[y][x] = math.NaN()
+				if cells != 0 {
+					values[y][x] = sum / float64(cells)
+				}
+			}
+			for i := range counts {
+				counts[i] = 0
+			}
+			for yi := y0; yi < y1; yi++ {
+				for xi := x0; xi < x1; xi++ {
+					if v := m.Cell(xi, yi); v >= 0 && v < len(counts) {
+						counts[v]++
+					}
+				}
+			}
+			content[y][x] = Ignored
+			for v, n := range counts {
+				if n > 0 && (content[y][x] == Ignored || n >= counts[content[y][x]]) {
+					content[y][x] = v
+				}
+			}
+		}
+	}
+
+	r := &Matrix{}
+	r.setContent(content, w, h, m.levels)
+	if values != nil {
+		r.setValues(values, m.lo, m.hi)
+	}
+	if weights != nil {
+		r.SetWeights(weights)
+	}
+	return r
+}
+
+// scaleRange returns the range of cells of a dimension of size `from` covered
+// by the cell `i` once it's resized to the size `to`
+func scaleRange(i, to, from int) (int, int) {
+	i0 := i * from / to
+	i1 := (i + 1) * from / to
+	if i1 <= i0 {
+		i1 = i0 + 1
+	}
+	return i0, i1
+}
+
+// Scale returns a new matrix with the size of this matrix multiplied by the
+// given factor
+func (m *Matrix) Scale(factor float64) *Matrix {
+	w, h := scaleSize(m.maxX, m.maxY, factor)
+	return m.Resize(w, h)
+}
+
+// validScale returns true if the scale factor is a finite number greater than 0
+func validScale(factor float64) bool {
+	return factor > 0 && !math.IsInf(factor, 0)
+}
+
+// scaleSize returns the size (w,h) multiplied by the factor, at least 1 and
+// at most `math.MaxInt32`
+func scaleSize(w, h int, factor float64) (int, int) {
+	size := func(n int) int {
+		return int(math.Max(1, math.Min(math.MaxInt32, math.Round(float64(n)*factor))))
+	}
+	return size(w), size(h)
+}
+
+// ParseScales returns the scale factors in a comma separated list
+func ParseScales(list string) ([]float64, error) {
+	scales := []float64{}
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if len(s) == 0 {
+			continue
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scale factor %q. %s", s, err)
+		}
+		if !validScale(f) {
+			return nil, fmt.Errorf("invalid scale factor %q, it has to be a finite number greater than 0", s)
+		}
+		scales = append(scales, f)
+	}
+	return scales, nil
+}
+
+// overlap returns the intersection of the areas of both matches over the
+// smaller area, on the torus
+func overlap(m, m1 Match, t torus) float64 {
+	i := t.intersection(m, m1)
+	smaller := m.Width * m.Height
+	if a := m1.Width * m1.Height; a < smaller {
+		smaller = a
+	}
+	if smaller == 0 {
+		return 0
+	}
+	return float64(i) / float64(smaller)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMatrix_Resize(t *testing.T) {
+	m := [][]int{
+		{1, 0, 0, 1},
+		{1, 1, 0, 0},
+	}
+	tests := []struct {
+		name string
+		w, h int
+		want [][]int
+	}{
+		{"same size", 4, 2, [][]int{{1, 0, 0, 1}, {1, 1, 0, 0}}},
+		{"double", 8, 4, [][]int{
+			{1, 1, 0, 0, 0, 0, 1, 1},
+			{1, 1, 0, 0, 0, 0, 1, 1},
+			{1, 1, 1, 1, 0, 0, 0, 0},
+			{1, 1, 1, 1, 0, 0, 0, 0},
+		}},
+		{"half", 2, 1, [][]int{{1, 0}}},
+		{"one row", 4, 1, [][]int{{1, 1, 0, 1}}},
+		{"one column", 1, 2, [][]int{{1}, {1}}},
+		{"empty", 0, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newTestMatrix(t, m).Resize(tt.w, tt.h)
+			if want := newTestMatrix(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("Matrix.Resize() = %v, want %v", got.Content(), want.Content())
+			}
+		})
+	}
+}
+
+func TestMatrix_Resize_ignored(t *testing.T) {
+	m := [][]int{
+		{Ignored, Ignored, 0, 1},
+		{Ignored, Ignored, 0, 0},
+		{1, 0, 0, Ignored},
+		{0, Ignored, 0, Ignored},
+	}
+	tests := []struct {
+		name string
+		w, h int
+		want [][]int
+	}{
+		{"half", 2, 2, [][]int{{Ignored, 0}, {0, 0}}},
+		{"double", 8, 2, [][]int{
+			{Ignored, Ignored, Ignored, Ignored, 0, 0, 1, 1},
+			{1, 1, 0, 0, 0, 0, Ignored, Ignored},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newTestMatrix(t, m).Resize(tt.w, tt.h)
+			if want := newTestMatrix(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("Matrix.Resize() = %v, want %v", got.Content(), want.Content())
+			}
+		})
+	}
+}
+
+func TestParseScales(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    string
+		want    []float64
+		wantErr bool
+	}{
+		{"empty", "", []float64{}, false},
+		{"one", "2", []float64{2}, false},
+		{"many", "0.5, 1,1.5,3", []float64{0.5, 1, 1.5, 3}, false},
+		{"not a number", "1,x", nil, true},
+		{"zero", "0", nil, true},
+		{"negative", "1,-2", nil, true},
+		{"NaN", "NaN", nil, true},
+		{"infinite", "1,+Inf", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScales(tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseScales() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseScales() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_overlap(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Match
+		m1   Match
+		want float64
+		t    torus
+	}{
+		{"same", Match{X: 2, Y: 3, Width: 4, Height: 4}, Match{X: 2, Y: 3, Width: 4, Height: 4}, 1, torus{}},
+		{"inside", Match{X: 0, Y: 0, Width: 10, Height: 10}, Match{X: 2, Y: 3, Width: 4, Height: 4}, 1, torus{}},
+		{"half", Match{X: 0, Y: 0, Width: 4, Height: 4}, Match{X: 2, Y: 0, Width: 8, Height: 8}, 0.5, torus{}},
+		{"apart", Match{X: 0, Y: 0, Width: 4, Height: 4}, Match{X: 4, Y: 0, Width: 4, Height: 4}, 0, torus{}},
+		{"apart wrapped", Match{X: 0, Y: 0, Width: 4, Height: 4}, Match{X: 8, Y: 0, Width: 8, Height: 4}, 0.5, torus{14, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overlap(tt.m, tt.m1, tt.t); got != tt.want {
+				t.Errorf("overlap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Search_scales(t *testing.T) {
+	target := [][]int{
+		{1, 1, 1, 1, 1, 1},
+		{1, 0, 0, 0, 0, 0},
+		{1, 1, 1, 0, 0, 0},
+		{1, 0, 0, 0, 0, 1},
+	}
+	tests := []struct {
+		name   string
+		scale  float64
+		scales []float64
+	}{
+		{"original", 1, []float64{0.5, 1, 2}},
+		{"double", 2, []float64{0.5, 1, 2, 3}},
+		{"triple", 3, []float64{1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaled := newTestMatrix(t, target).Scale(tt.scale)
+			content := make([][]int, 30)
+			for y := range content {
+				content[y] = make([]int, 40)
+			}
+			w, h := scaled.Size()
+			for y := 0; y < h; y++ {
+				copy(content[y+5][9:], scaled.Content()[y])
+			}
+
+			f := New(DefaultOne, DefaultZero, 100, 1)
+			f.Source = newTestMatrix(t, content)
+			f.Target = newTestMatrix(t, target)
+			f.Scales = tt.scales
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			// the size is in the match only if it's not the target size
+			want := []Match{{X: 9, Y: 5, Percentage: 100, Width: w, Height: h, Scale: tt.scale}}
+			if tt.scale == 1 {
+				want[0].Width, want[0].Height = 0, 0
+			}
+			if !reflect.DeepEqual(f.Matches, want) {
+				t.Fatalf("Finder2D.Search() = %v, want %v", f.Matches, want)
+			}
+			if gotW, gotH := f.MatchSize(f.Matches[0]); gotW != w || gotH != h {
+				t.Errorf("Finder2D.MatchSize() = (%d,%d), want (%d,%d)", gotW, gotH, w, h)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Search_invalidScales(t *testing.T) {
+	tests := []struct {
+		name    string
+		scales  []float64
+		want    int
+		wantErr bool
+	}{
+		{"NaN", []float64{1, math.NaN()}, 0, true},
+		{"infinite", []float64{math.Inf(1)}, 0, true},
+		{"zero", []float64{0}, 0, true},
+		{"larger than the source", []float64{1, 1e9, math.MaxFloat64}, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(DefaultOne, DefaultZero, 100, 1)
+			f.Source = newTestMatrix(t, [][]int{{0, 0, 0}, {0, 1, 1}, {0, 1, 1}})
+			f.Target = newTestMatrix(t, [][]int{{1, 1}, {1, 1}})
+			f.Scales = tt.scales
+			if err := f.Search(); (err != nil) != tt.wantErr {
+				t.Fatalf("Finder2D.Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(f.Matches) != tt.want {
+				t.Errorf("Finder2D.Search() = %v, want %d matches", f.Matches, tt.want)
+			}
+		})
+	}
+}
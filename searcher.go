@@ -0,0 +1,204 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultStrategy is the name of the search strategy used when none is given
+const DefaultStrategy = "simple"
+
+// SearchParams are the parameters to compare the target with every area of
+// the source
+type SearchParams struct {
+	// Percentage is the minimum score of a match
+	Percentage float64
+	// Metric is the scoring metric, the default is `Agreement`
+	Metric Metric
+	// Comparison is how the cells of matrixes with more than two levels are
+	// compared, the default is `CompareExact`
+	Comparison Comparison
+	// Tolerance is the maximum difference of the values of two equal cells
+	// with `CompareTolerance`
+	Tolerance float64
+}
+
+// Searcher is implemented by every search strategy. Search returns every
+// position of the target in the source with a score, in the metric of the
+// given parameters, equal or higher than the parameters percentage, in scan
+// order (top to bottom, left to right). The score is calculated from the
+// confusion counts weighted by the target weights, if any. The matches are not
+// reduced, that is done by the Finder2D. The search should stop as soon as the
+// context is done, returning the context error.
+type Searcher interface {
+	Search(ctx context.Context, source, target *Matrix, params SearchParams) ([]Match, error)
+}
+
+// targetsSearcher is implemented by the search strategies that search several
+// targets in a single pass of the source. searchTargets returns the matches of
+// every target with the top-left corner in the rows from `y0` to `y1` (not
+// included), like Search, visiting every position of the source once and
+// comparing at it every target that may be there
+type targetsSearcher interface {
+	searchTargets(ctx context.Context, source *Matrix, targets []scanTarget, params SearchParams, y0, y1 int) ([][]Match, error)
+}
+
+// scanTarget is a target to search in a single pass of the source, with the
+// top-left corner at the positions (x,y) with `x < cols` and `y < rows`. The
+// target has to be inside the source at all of them
+type scanTarget struct {
+	target     *Matrix
+	cols, rows int
+}
+
+// newScanTarget returns the target to search at every position of the source
+// where it fits
+func newScanTarget(source, target *Matrix) scanTarget {
+	maxX, maxY := source.Size()
+	width, height := target.Size()
+	if width+height == 0 || width > maxX || height > maxY {
+		return scanTarget{target: target}
+	}
+	return scanTarget{target, maxX - width + 1, maxY - height + 1}
+}
+
+// SearcherFunc is an adapter to use ordinary functions as a Searcher
+type SearcherFunc func(ctx context.Context, source, target *Matrix, params SearchParams) ([]Match, error)
+
+// Search calls fn(ctx, source, target, params)
+func (fn SearcherFunc) Search(ctx context.Context, source, target *Matrix, params SearchParams) ([]Match, error) {
+	return fn(ctx, source, target, params)
+}
+
+var (
+	searchersMu sync.RWMutex
+	searchers   = map[string]Searcher{}
+)
+
+func init() {
+	RegisterSearcher(DefaultStrategy, simpleSearcher{})
+}
+
+// RegisterSearcher makes a search strategy available by the given name. If
+// RegisterSearcher is called twice with the same name or if the searcher is
+// nil, it panics
+func RegisterSearcher(name string, s Searcher) {
+	searchersMu.Lock()
+	defer searchersMu.Unlock()
+
+	if s == nil {
+		panic("finder2d: RegisterSearcher searcher is nil")
+	}
+	if _, dup := searchers[name]; dup {
+		panic("finder2d: RegisterSearcher called twice for searcher " + name)
+	}
+	searchers[name] = s
+}
+
+// GetSearcher returns the search strategy registered with the given name. An
+// empty name returns the default strategy
+func GetSearcher(name string) (Searcher, error) {
+	if len(name) == 0 {
+		name = DefaultStrategy
+	}
+
+	searchersMu.RLock()
+	defer searchersMu.RUnlock()
+
+	s, ok := searchers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown search strategy %q. Available strategies are: %s", name, strings.Join(searcherNames(), ", "))
+	}
+	return s, nil
+}
+
+// Searchers returns the sorted list of the registered search strategies names
+func Searchers() []string {
+	searchersMu.RLock()
+	defer searchersMu.RUnlock()
+
+	return searcherNames()
+}
+
+func searcherNames() []string {
+	names := make([]string, 0, len(searchers))
+	for name := range searchers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// simpleSearcher is the brute force strategy, see searchSimple. It also
+// searches several targets in a single pass of the source
+type simpleSearcher struct{}
+
+// Search calls searchSimple
+func (simpleSearcher) Search(ctx context.Context, source, target *Matrix, params SearchParams) ([]Match, error) {
+	return searchSimple(ctx, source, target, params)
+}
+
+func (simpleSearcher) searchTargets(ctx context.Context, source *Matrix, targets []scanTarget, params SearchParams, y0, y1 int) ([][]Match, error) {
+	maxX, _ := source.Size()
+	matches := make([][]Match, len(targets))
+	for i := range matches {
+		matches[i] = []Match{}
+	}
+
+	for y := y0; y < y1; y++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for x := 0; x < maxX; x++ {
+			for i, t := range targets {
+				if x >= t.cols || y >= t.rows {
+					continue
+				}
+				p := params.Metric.Score(source.confusionAt(x, y, t.target, params))
+				if p >= params.Percentage {
+					matches[i] = append(matches[i], Match{
+						X:          x,
+						Y:          y,
+						Percentage: p,
+					})
+				}
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// searchSimple is the brute force strategy, it iterates thru the entire source
+// matrix comparing the target with the area of the target size at every
+// position, without sampling the source. The bit packed rows of binary
+// matrixes are compared with XOR and popcount, the cells of other matrixes one
+// by one. This is the reference implementation every other strategy has to
+// agree with
+func searchSimple(ctx context.Context, source, target *Matrix, params SearchParams) ([]Match, error) {
+	t := newScanTarget(source, target)
+	matches, err := simpleSearcher{}.searchTargets(ctx, source, []scanTarget{t}, params, 0, t.rows)
+	if err != nil {
+		return nil, err
+	}
+	return matches[0], nil
+}
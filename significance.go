@@ -0,0 +1,181 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"fmt"
+	"math"
+)
+
+// pValues sets the p-value of every match and, if `MaxPValue` is set, removes
+// the matches with a higher p-value. The p-value is the probability of a random
+// source with the same frequency of every level, i.e. the density of ones, to
+// have at least the same number of cells equal to the target than the matched
+// area, the tail of the sum of the binomial distributions of the cells of every
+// level of the target
+func (f *Finder2D) pValues(matches []Match, variants []variant) []Match {
+	freqs := f.Source.levelFrequencies()
+	tails := map[string][]float64{}
+	kept := matches[:0]
+	for _, m := range matches {
+		target := f.Target
+		for _, v := range variants {
+			if v.name == m.Target && v.orientation == m.Orientation && v.scale == m.Scale {
+				target = v.target
+				break
+			}
+		}
+		equal, counts := f.Source.equalCellsAt(m.X, m.Y, target, f.Wrap)
+		key := fmt.Sprint(counts)
+		tail, ok := tails[key]
+		if !ok {
+			tail = tailProbabilities(counts, freqs)
+			tails[key] = tail
+		}
+		m.PValue = tail[equal]
+		if f.MaxPValue == 0 || m.PValue <= f.MaxPValue {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// levelFrequencies returns the frequency of every level in the cells of the
+// matrix that are not ignored
+func (m *Matrix) levelFrequencies() []float64 {
+	freqs := make([]float64, m.Levels())
+	var n float64
+	for y := range m.bits {
+		for x := 0; x < m.maxX; x++ {
+			cell := m.Cell(x, y)
+			if cell == Ignored || cell >= len(freqs) {
+				continue
+			}
+			freqs[cell]++
+			n++
+		}
+	}
+	for l := range freqs {
+		if n != 0 {
+			freqs[l] /= n
+		}
+	}
+	return freqs
+}
+
+// equalCellsAt returns the number of cells of the target equal to the area of
+// the matrix starting at (x,y), and the number of compared cells of every
+// level of the target. The cells ignored in any of them, or out of the matrix,
+// are not compared. With wrap the area continues at the left and top of the
+// matrix, as if it were a torus
+func (m *Matrix) equalCellsAt(x, y int, target *Matrix, wrap bool) (equal int, counts []int) {
+	counts = make([]int, m.Levels())
+	for yi := range target.bits {
+		sy := y + yi
+		if wrap {
+			sy = mod(sy, m.maxY)
+		}
+		if sy < 0 || sy >= m.maxY {
+			continue
+		}
+		for xi := 0; xi < target.maxX; xi++ {
+			sx := x + xi
+			if wrap {
+				sx = mod(sx, m.maxX)
+			}
+			if sx < 0 || sx >= m.maxX {
+				continue
+			}
+			s, cell := m.Cell(sx, sy), target.Cell(xi, yi)
+			if s == Ignored || cell == Ignored || cell >= len(counts) {
+				continue
+			}
+			counts[cell]++
+			if s == cell {
+				equal++
+			}
+		}
+	}
+	return equal, counts
+}
+
+// tailProbabilities returns the probability of every number of equal cells or
+// more, from 0 to the number of compared cells, where every cell of the level
+// `l` is equal with the probability `freqs[l]`. The distribution is computed
+// in logarithms so the tiny probabilities of large targets don't underflow
+func tailProbabilities(counts []int, freqs []float64) []float64 {
+	// dist is the logarithm of the probability of every number of equal cells
+	dist := []float64{0}
+	for l, n := range counts {
+		if n == 0 {
+			continue
+		}
+		binomial := logBinomial(n, freqs[l])
+		next := make([]float64, len(dist)+n)
+		for k := range next {
+			next[k] = math.Inf(-1)
+		}
+		for i, a := range dist {
+			for j, b := range binomial {
+				next[i+j] = logSum(next[i+j], a+b)
+			}
+		}
+		dist = next
+	}
+
+	tail := make([]float64, len(dist))
+	acc := math.Inf(-1)
+	for k := len(dist) - 1; k >= 0; k-- {
+		acc = logSum(acc, dist[k])
+		tail[k] = math.Min(1, math.Exp(acc))
+	}
+	return tail
+}
+
+// logBinomial returns the logarithm of the probability of every number of
+// successes of n trials with the probability p
+func logBinomial(n int, p float64) []float64 {
+	dist := make([]float64, n+1)
+	for k := range dist {
+		switch {
+		case p == 0 && k == 0, p == 1 && k == n:
+			dist[k] = 0
+		case p == 0 || p == 1:
+			dist[k] = math.Inf(-1)
+		default:
+			ln, _ := math.Lgamma(float64(n + 1))
+			lk, _ := math.Lgamma(float64(k + 1))
+			lnk, _ := math.Lgamma(float64(n - k + 1))
+			dist[k] = ln - lk - lnk + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p)
+		}
+	}
+	return dist
+}
+
+// logSum returns log(e^a + e^b) without overflow or underflow
+func logSum(a, b float64) float64 {
+	if math.IsInf(a, -1) {
+		return b
+	}
+	if math.IsInf(b, -1) {
+		return a
+	}
+	if a < b {
+		a, b = b, a
+	}
+	return a + math.Log1p(math.Exp(b-a))
+}
@@ -0,0 +1,161 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFinder2D_Search_significance(t *testing.T) {
+	f := loadTestFinder(t, 60, 1)
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	all := len(f.Matches)
+	for _, m := range f.Matches {
+		if m.PValue != 0 {
+			t.Fatalf("Finder2D.Search() without significance has p-value %v, want 0", m.PValue)
+		}
+	}
+
+	f.Significance = true
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if len(f.Matches) != all {
+		t.Fatalf("Finder2D.Search() with significance found %d matches, want %d", len(f.Matches), all)
+	}
+	for _, m := range f.Matches {
+		if m.PValue <= 0 || m.PValue >= 1e-10 {
+			t.Errorf("Finder2D.Search() match at (%d,%d) with %v%% has p-value %v, want a tiny p-value", m.X, m.Y, m.Percentage, m.PValue)
+		}
+	}
+
+	// only the 6 cats are that significant
+	f.Significance, f.MaxPValue = false, 1e-50
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if len(f.Matches) != 6 {
+		t.Errorf("Finder2D.Search() with maximum p-value found %d matches, want 6", len(f.Matches))
+	}
+
+	f.MaxPValue = 2
+	if err := f.Search(); err == nil {
+		t.Errorf("Finder2D.Search() with maximum p-value 2 error = nil, want an error")
+	}
+}
+
+func TestFinder2D_Search_significance_topK(t *testing.T) {
+	// the partial match at the left border has the highest percentage but
+	// compares fewer cells, so its p-value is higher than the inner match
+	content := make([][]int, 8)
+	for y := range content {
+		content[y] = make([]int, 12)
+	}
+	for y := 2; y < 6; y++ {
+		for _, x := range []int{0, 1, 2, 6, 7, 8, 9} {
+			content[y][x] = 1
+		}
+	}
+	content[5][9] = 0
+	target := [][]int{{1, 1, 1, 1}, {1, 1, 1, 1}, {1, 1, 1, 1}, {1, 1, 1, 1}}
+
+	tests := []struct {
+		name       string
+		percentage float64
+		maxPValue  float64
+		want       []Match
+	}{
+		{"top match", 90, 0, []Match{{X: -1, Y: 2, Percentage: 100, Visible: 0.75}}},
+		{"top significant match", 90, 1e-7, []Match{{X: 6, Y: 2, Percentage: 93.75, Visible: 1}}},
+		{"best significant candidate", 100, 1e-7, []Match{{X: 6, Y: 2, Percentage: 93.75, Visible: 1}}},
+		{"no significant candidate", 100, 1e-20, []Match{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(DefaultOne, DefaultZero, tt.percentage, 1)
+			f.Source = newTestMatrix(t, content)
+			f.Target = newTestMatrix(t, target)
+			f.Border, f.TopK, f.MaxPValue = true, 1, tt.maxPValue
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			for i := range f.Matches {
+				f.Matches[i].PValue = 0
+			}
+			if !reflect.DeepEqual(f.Matches, tt.want) {
+				t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tailProbabilities(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int
+		freqs  []float64
+		want   []float64
+	}{
+		{"fair", []int{2, 1}, []float64{0.5, 0.5}, []float64{1, 7.0 / 8, 4.0 / 8, 1.0 / 8}},
+		{"density", []int{1, 1}, []float64{0.25, 0.75}, []float64{1, 0.8125, 0.1875}},
+		{"no ones", []int{1, 1}, []float64{1, 0}, []float64{1, 1, 0}},
+		{"no cells", []int{0, 0}, []float64{0.5, 0.5}, []float64{1}},
+		{"levels", []int{1, 0, 1}, []float64{0.5, 0.3, 0.2}, []float64{1, 0.6, 0.1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tailProbabilities(tt.counts, tt.freqs)
+			if len(got) != len(tt.want) {
+				t.Fatalf("tailProbabilities() = %v, want %v", got, tt.want)
+			}
+			for k := range got {
+				if math.Abs(got[k]-tt.want[k]) > 1e-12 {
+					t.Fatalf("tailProbabilities() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix_levelFrequencies(t *testing.T) {
+	m := newTestMatrix(t, [][]int{
+		{0, 1, 1, Ignored},
+		{0, 0, 1, 0},
+	})
+	want := []float64{4.0 / 7, 3.0 / 7}
+	got := m.levelFrequencies()
+	if len(got) != len(want) || math.Abs(got[0]-want[0]) > 1e-12 || math.Abs(got[1]-want[1]) > 1e-12 {
+		t.Errorf("Matrix.levelFrequencies() = %v, want %v", got, want)
+	}
+
+	target := newTestMatrix(t, [][]int{{1, 1}, {0, 1}})
+	equal, counts := m.equalCellsAt(2, 0, target, false)
+	if equal != 1 || counts[0] != 1 || counts[1] != 2 {
+		t.Errorf("Matrix.equalCellsAt() = %d, %v, want 1, [1 2]", equal, counts)
+	}
+	// the cells out of the right border are not compared, unless it wraps
+	if equal, counts := m.equalCellsAt(3, 0, target, false); equal != 1 || counts[0] != 1 || counts[1] != 0 {
+		t.Errorf("Matrix.equalCellsAt() = %d, %v, want 1, [1 0]", equal, counts)
+	}
+	if equal, counts := m.equalCellsAt(3, 0, target, true); equal != 1 || counts[0] != 1 || counts[1] != 2 {
+		t.Errorf("Matrix.equalCellsAt() with wrap = %d, %v, want 1, [1 2]", equal, counts)
+	}
+}
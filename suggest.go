@@ -0,0 +1,221 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// suggestionBarWidth is the length of the bar of the largest bin of the
+// histogram in the text of the suggestion
+const suggestionBarWidth = 50
+
+// Suggestion is the recommended percentage and delta to search the target in
+// the source, computed from the distribution of the scores of the target at
+// every position of the source
+type Suggestion struct {
+	// Percentage is the threshold separating the scores of the background from
+	// the scores of the matches
+	Percentage float64
+	// Delta is the distance to group the positions with a score over the
+	// percentage into the images found
+	Delta int
+	// Images is the number of images found with the suggested values
+	Images int
+	// Histogram is the number of positions with a score in every bin of 1%,
+	// from `HistogramLow`. The last bin also has the scores of 100%
+	Histogram    []int
+	HistogramLow float64
+}
+
+// Suggest recommends the percentage and delta of the search. The percentage is
+// in the valley separating the scores of the background, the most of the
+// positions, from the higher scores of the matches. The delta is the longest
+// link between the positions with a score over the percentage needed to group
+// them, transitively, in images, where the positions closer than half the
+// smaller side of the target are the same image
+func (f *Finder2D) Suggest(ctx context.Context) (*Suggestion, error) {
+	h, err := f.Heatmap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s := &Suggestion{HistogramLow: h.lowest()}
+	s.Histogram = make([]int, int(100-s.HistogramLow))
+	for _, row := range h.Scores {
+		for _, score := range row {
+			bin := int(score - s.HistogramLow)
+			if bin >= len(s.Histogram) {
+				bin = len(s.Histogram) - 1
+			}
+			s.Histogram[bin]++
+		}
+	}
+	s.Percentage = valleyHistogram(s.Histogram, s.HistogramLow)
+
+	tw, th := f.Target.Size()
+	limit := tw
+	if th < limit {
+		limit = th
+	}
+	s.Delta, s.Images = groupScores(h, s.Percentage, limit/2)
+	return s, nil
+}
+
+// String returns the suggested values and the histogram as text, a line per
+// bin from the lowest to the highest bin with scores
+func (s *Suggestion) String() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Percentage: %g\nDelta: %d\nImages: %d\n", s.Percentage, s.Delta, s.Images)
+	first, last, max := -1, -1, 0
+	for i, n := range s.Histogram {
+		if n == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+		if n > max {
+			max = n
+		}
+	}
+	if first < 0 {
+		return b.String()
+	}
+	b.WriteString("Histogram:\n")
+	for i := first; i <= last; i++ {
+		n := s.Histogram[i]
+		bar := (n*suggestionBarWidth + max - 1) / max
+		fmt.Fprintf(&b, "%4.0f%% %8d %s\n", s.HistogramLow+float64(i), n, strings.Repeat("#", bar))
+	}
+	return b.String()
+}
+
+// valleyHistogram returns the threshold splitting the histogram of the scores,
+// with the lowest bin starting at `low`, in the scores of the background and
+// the higher scores of the matches, with the Otsu's method. The split has the
+// maximum variance between both classes, so a few scores in the valley do not
+// move it like they move the widest gap. The background has many more scores
+// than the matches, so every bin weights the logarithm of its count, otherwise
+// the split is inside the background. The threshold is in the middle of the
+// empty bins after the background, if any. If there is no split it's the
+// lowest score of the highest bin
+func valleyHistogram(histogram []int, low float64) float64 {
+	weights := make([]float64, len(histogram))
+	var total, sum float64
+	highest := -1
+	for i, n := range histogram {
+		weights[i] = math.Log1p(float64(n))
+		total += weights[i]
+		sum += float64(i) * weights[i]
+		if n != 0 {
+			highest = i
+		}
+	}
+	if highest < 0 {
+		return low
+	}
+
+	split, best := -1, 0.0
+	var background, backgroundSum float64
+	for i := 0; i < highest; i++ {
+		background += weights[i]
+		backgroundSum += float64(i) * weights[i]
+		if background == 0 {
+			continue
+		}
+		matches := total - background
+		mBackground := backgroundSum / background
+		mMatches := (sum - backgroundSum) / matches
+		variance := background * matches * (mBackground - mMatches) * (mBackground - mMatches)
+		if variance > best {
+			// the background are the scores up to the bin i
+			split, best = i, variance
+		}
+	}
+	if split < 0 {
+		return low + float64(highest)
+	}
+
+	// the first bin of the matches after the empty bins of the valley
+	first := split + 1
+	for histogram[first] == 0 {
+		first++
+	}
+	return low + float64(split+1+first)/2
+}
+
+// groupScores returns the delta to group, transitively, the positions of the
+// heatmap with a score equal or higher than the percentage in images, and the
+// number of images. Positions at a distance of the limit or farther are never
+// the same image. The delta is at least `MinDelta`
+func groupScores(h *Heatmap, percentage float64, limit int) (delta, images int) {
+	type point struct{ x, y int }
+	var points []point
+	index := map[point]int{}
+	for y, row := range h.Scores {
+		for x, score := range row {
+			if score >= percentage {
+				index[point{x, y}] = len(points)
+				points = append(points, point{x, y})
+			}
+		}
+	}
+
+	// union-find of the points, joined by increasing distance
+	parent := make([]int, len(points))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	images = len(points)
+	delta = MinDelta
+	for d := 1; d < limit; d++ {
+		for i, p := range points {
+			// the points at distance d, in the square ring around p
+			for dy := -d; dy <= d; dy++ {
+				for dx := -d; dx <= d; dx++ {
+					if dx != -d && dx != d && dy != -d && dy != d {
+						continue
+					}
+					j, ok := index[point{p.x + dx, p.y + dy}]
+					if !ok {
+						continue
+					}
+					if ri, rj := find(i), find(j); ri != rj {
+						parent[ri] = rj
+						images--
+						if d > delta {
+							delta = d
+						}
+					}
+				}
+			}
+		}
+	}
+	return delta, images
+}
@@ -0,0 +1,130 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFinder2D_Suggest(t *testing.T) {
+	f := loadTestFinder(t, 50, 1)
+	s, err := f.Suggest(context.Background())
+	if err != nil {
+		t.Fatalf("Finder2D.Suggest() error = %v", err)
+	}
+	if s.Images != 6 {
+		t.Errorf("Suggestion.Images = %d, want 6", s.Images)
+	}
+	if s.HistogramLow != 0 || len(s.Histogram) != 100 {
+		t.Errorf("Suggestion.Histogram from %v has %d bins, want 100 bins from 0", s.HistogramLow, len(s.Histogram))
+	}
+	width, height := f.Source.Size()
+	tw, th := f.Target.Size()
+	total := 0
+	for _, n := range s.Histogram {
+		total += n
+	}
+	if want := (width - tw + 1) * (height - th + 1); total != want {
+		t.Errorf("Suggestion.Histogram has %d scores, want %d", total, want)
+	}
+
+	// the suggested values find the 6 cats
+	f.Percentage, f.Delta = s.Percentage, s.Delta
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if len(f.Matches) != 6 {
+		t.Errorf("Finder2D.Search() with the suggestion found %d matches, want 6", len(f.Matches))
+	}
+
+	if !strings.HasPrefix(s.String(), "Percentage: ") {
+		t.Errorf("Suggestion.String() = %q, want the suggested values first", s.String())
+	}
+
+	if _, err := New(DefaultOne, DefaultZero, 50, 1).Suggest(context.Background()); err == nil {
+		t.Errorf("Finder2D.Suggest() without matrixes error = nil, want an error")
+	}
+}
+
+func Test_valleyHistogram(t *testing.T) {
+	// bins is a histogram from 0 with the given counts at some bins
+	bins := func(counts map[int]int) []int {
+		h := make([]int, 100)
+		for i, n := range counts {
+			h[i] = n
+		}
+		return h
+	}
+	tests := []struct {
+		name      string
+		histogram []int
+		low       float64
+		want      float64
+	}{
+		{"empty", bins(nil), 0, 0},
+		{"single bin", bins(map[int]int{42: 3}), 0, 42},
+		{"outliers", bins(map[int]int{10: 1, 12: 1, 14: 1, 15: 1, 18: 1, 20: 1, 90: 1, 95: 1}), 0, 55.5},
+		{"from -100", bins(map[int]int{10: 5, 11: 9, 60: 2}), -100, -64},
+		// the widest gap is inside the matches, between 85% and 96%, the
+		// valley between the populations has a few noisy scores
+		{"noisy valley", bins(map[int]int{
+			20: 30, 22: 60, 25: 90, 28: 120, 30: 150, 32: 120, 35: 90, 38: 60, 40: 30,
+			46: 2, 51: 1, 57: 1, 63: 2,
+			70: 3, 74: 6, 77: 9, 80: 6, 85: 3, 96: 1,
+		}), 0, 54.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := valleyHistogram(tt.histogram, tt.low); got != tt.want {
+				t.Errorf("valleyHistogram() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_groupScores(t *testing.T) {
+	h := &Heatmap{Scores: [][]float64{
+		{90, 90, 0, 0, 0, 0},
+		{0, 0, 0, 90, 0, 0},
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 90},
+	}}
+	tests := []struct {
+		name       string
+		percentage float64
+		limit      int
+		wantDelta  int
+		wantImages int
+	}{
+		{"no limit", 50, 1, MinDelta, 4},
+		{"adjacent", 50, 2, 1, 3},
+		{"transitive", 50, 3, 2, 2},
+		{"all", 50, 4, 3, 1},
+		{"no scores", 100, 4, MinDelta, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta, images := groupScores(h, tt.percentage, tt.limit)
+			if delta != tt.wantDelta || images != tt.wantImages {
+				t.Errorf("groupScores() = (%d, %d), want (%d, %d)", delta, images, tt.wantDelta, tt.wantImages)
+			}
+		})
+	}
+}
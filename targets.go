@@ -0,0 +1,89 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// namedTarget is a target to search with the name of the target in the library
+// or empty for the target in `Target`
+type namedTarget struct {
+	name   string
+	target *Matrix
+}
+
+// AddTarget loads a target from a reader, replacing the cell value given in
+// `one` for `1`, `zero` for `0` and `ignore` for `Ignored`, and adds it to the
+// library of targets with the given name. A target with the same name is
+// replaced. The simple strategy searches all the targets of the library in a
+// single pass of the source
+func (f *Finder2D) AddTarget(name string, r io.Reader) error {
+	if len(name) == 0 {
+		return fmt.Errorf("the target name is required")
+	}
+	m, err := f.loadMatrix(r)
+	if err != nil {
+		return err
+	}
+
+	if f.targets == nil {
+		f.targets = map[string]*Matrix{}
+	}
+	f.targets[name] = m
+	return nil
+}
+
+// RemoveTarget removes the target with the given name from the library
+func (f *Finder2D) RemoveTarget(name string) error {
+	if _, ok := f.targets[name]; !ok {
+		return fmt.Errorf("target %q not found", name)
+	}
+	delete(f.targets, name)
+	return nil
+}
+
+// Targets returns the sorted names of the targets in the library
+func (f *Finder2D) Targets() []string {
+	names := make([]string, 0, len(f.targets))
+	for name := range f.targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetTarget returns the target in the library with the given name, or nil if
+// it's not in the library
+func (f *Finder2D) GetTarget(name string) *Matrix {
+	return f.targets[name]
+}
+
+// searchTargets returns the targets to search, the target in `Target`, if set,
+// followed by the targets in the library sorted by name
+func (f *Finder2D) searchTargets() []namedTarget {
+	targets := []namedTarget{}
+	if f.Target != nil {
+		targets = append(targets, namedTarget{"", f.Target})
+	}
+	for _, name := range f.Targets() {
+		targets = append(targets, namedTarget{name, f.targets[name]})
+	}
+	return targets
+}
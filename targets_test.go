@@ -0,0 +1,181 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFinder2D_Targets(t *testing.T) {
+	f := New(DefaultOne, DefaultZero, 0, 0)
+	for _, name := range []string{"dog", "cat", "bird"} {
+		if err := f.AddTarget(name, strings.NewReader("+ +\n + \n")); err != nil {
+			t.Fatalf("Finder2D.AddTarget(%q) error = %v", name, err)
+		}
+	}
+	if err := f.AddTarget("", strings.NewReader("+\n")); err == nil {
+		t.Errorf("Finder2D.AddTarget() with empty name expected an error")
+	}
+	if err := f.AddTarget("cat", strings.NewReader("++\n +\n")); err != nil {
+		t.Fatalf("Finder2D.AddTarget() replacing error = %v", err)
+	}
+	if w, h := f.GetTarget("cat").Size(); w != 2 || h != 2 {
+		t.Errorf("Finder2D.GetTarget() size = (%d,%d), want (2,2)", w, h)
+	}
+
+	if got, want := f.Targets(), []string{"bird", "cat", "dog"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Finder2D.Targets() = %v, want %v", got, want)
+	}
+
+	if err := f.RemoveTarget("dog"); err != nil {
+		t.Fatalf("Finder2D.RemoveTarget() error = %v", err)
+	}
+	if err := f.RemoveTarget("dog"); err == nil {
+		t.Errorf("Finder2D.RemoveTarget() of a removed target expected an error")
+	}
+	if got, want := f.Targets(), []string{"bird", "cat"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Finder2D.Targets() = %v, want %v", got, want)
+	}
+	if f.GetTarget("dog") != nil {
+		t.Errorf("Finder2D.GetTarget() of a removed target is not nil")
+	}
+}
+
+func TestFinder2D_Search_targets(t *testing.T) {
+	single := loadTestFinder(t, 70, 1)
+	if err := single.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+
+	// the same target with two names is found twice, the matches of different
+	// targets are not reduced
+	want := []Match{}
+	for _, m := range single.Matches {
+		for _, name := range []string{"cat", "kitty"} {
+			m.Target = name
+			want = append(want, m)
+		}
+	}
+
+	f := loadTestFinder(t, 70, 1)
+	f.Target = nil
+	for _, name := range []string{"kitty", "cat"} {
+		target, err := os.Open("test_data/perfect_cat_image.txt")
+		if err != nil {
+			t.Fatalf("failed to open the target matrix. %s", err)
+		}
+		err = f.AddTarget(name, target)
+		target.Close()
+		if err != nil {
+			t.Fatalf("Finder2D.AddTarget() error = %v", err)
+		}
+	}
+
+	for _, strategy := range Searchers() {
+		t.Run(strategy, func(t *testing.T) {
+			f.Strategy = strategy
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			if !reflect.DeepEqual(f.Matches, want) {
+				t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Search_targets_singlePass(t *testing.T) {
+	content := make([][]int, 16)
+	for y := range content {
+		content[y] = make([]int, 24)
+		for x := range content[y] {
+			if (x*7+y*13)%5 == 0 || (x*y)%7 == 1 {
+				content[y][x] = 1
+			}
+		}
+	}
+	source := newTestMatrix(t, content)
+	targets := map[string]*Matrix{
+		"small": source.Sample(3, 2, 3, 2),
+		"large": source.Sample(10, 5, 5, 4),
+		"tall":  source.Sample(20, 11, 2, 5),
+	}
+	newFinder := func(strategy string) *Finder2D {
+		f := New(DefaultOne, DefaultZero, 70, 1)
+		f.Source = source
+		f.targets = targets
+		f.Strategy = strategy
+		return f
+	}
+
+	// the simple strategy scans the source once for all the targets, the fft
+	// strategy once for every target, both find the same matches
+	tests := []struct {
+		name   string
+		modify func(f *Finder2D)
+	}{
+		{"default", func(f *Finder2D) {}},
+		{"workers", func(f *Finder2D) { f.Workers = 3 }},
+		{"border", func(f *Finder2D) { f.Border, f.MinVisible = true, 0.5 }},
+		{"wrap", func(f *Finder2D) { f.Wrap = true }},
+		{"orientations and scales", func(f *Finder2D) { f.AnyOrientation, f.Scales = true, []float64{1, 2} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := newFinder("fft")
+			tt.modify(want)
+			if err := want.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			if len(want.Matches) == 0 {
+				t.Fatalf("Finder2D.Search() found no matches")
+			}
+
+			f := newFinder(DefaultStrategy)
+			tt.modify(f)
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			if !reflect.DeepEqual(f.Matches, want.Matches) {
+				t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want.Matches)
+			}
+		})
+	}
+
+	// the rows scanned are those of a single pass, the rows of the shortest
+	// target, not the rows of every target
+	var wantTotal, gotTotal int
+	for _, strategy := range []string{"fft", DefaultStrategy} {
+		f := newFinder(strategy)
+		opts := &SearchOptions{Progress: func(_, total int) { gotTotal = total }}
+		if err := f.SearchContext(context.Background(), opts); err != nil {
+			t.Fatalf("Finder2D.SearchContext() error = %v", err)
+		}
+		if strategy == "fft" {
+			wantTotal = 15 + 13 + 12
+		} else {
+			wantTotal = 15
+		}
+		if gotTotal != wantTotal {
+			t.Errorf("Finder2D.SearchContext() with %s scanned %d rows, want %d", strategy, gotTotal, wantTotal)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// valueLevels is the number of levels of the content of a matrix of values,
+// the level of every value in the range of the values
+const valueLevels = 256
+
+// NewValuesMatrix creates a matrix with the given numeric values, i.e. the
+// intensity of every pixel of a grayscale image. All the rows have to be the
+// same width and the `NaN` values are ignored cells. The content of the matrix
+// is the level of every value in the range of the values, from `0` to `255`
+func NewValuesMatrix(values [][]float64) (*Matrix, error) {
+	m := &Matrix{}
+	if len(values) == 0 {
+		return m, nil
+	}
+	m.maxX, m.maxY = len(values[0]), len(values)
+	for y, row := range values {
+		if len(row) != m.maxX {
+			return nil, fmt.Errorf("matrix width = %d, especified by the first row, is different at row #%d (%d)", m.maxX, y, len(row))
+		}
+		for x, v := range row {
+			if math.IsInf(v, 0) {
+				return nil, fmt.Errorf("invalid value %v at (%d,%d), it has to be a finite number", v, x, y)
+			}
+		}
+	}
+	lo, hi := valuesRange(values)
+	m.setValues(values, lo, hi)
+
+	return m, nil
+}
+
+// LoadMatrixValues creates a matrix of numeric values from a reader
+func LoadMatrixValues(r io.Reader, ignore rune) (*Matrix, error) {
+	m := &Matrix{}
+	err := m.LoadValues(r, ignore)
+	return m, err
+}
+
+// LoadValues loads a matrix of numeric values from a reader. Every line is a
+// row with the values separated by spaces, tabs or commas, the empty lines are
+// ignored. The value given in `ignore`, if not `0`, is an ignored cell
+func (m *Matrix) LoadValues(r io.Reader, ignore rune) error {
+	values, err := readNumbers(r, "value", func(field string) (float64, error) {
+		if ignore != 0 && field == string(ignore) {
+			return math.NaN(), nil
+		}
+		return strconv.ParseFloat(field, 64)
+	})
+	if err != nil {
+		return err
+	}
+	vm, err := NewValuesMatrix(values)
+	if err != nil {
+		return err
+	}
+	*m = *vm
+	return nil
+}
+
+// readNumbers reads a matrix of numbers from a reader, every line is a row
+// with the numbers separated by spaces, tabs or commas. The empty lines are
+// ignored
+func readNumbers(r io.Reader, name string, parse func(field string) (float64, error)) ([][]float64, error) {
+	numbers := [][]float64{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.FieldsFunc(scanner.Text(), func(c rune) bool {
+			return c == ' ' || c == '\t' || c == ','
+		})
+		if len(fields) == 0 {
+			continue
+		}
+		row := make([]float64, len(fields))
+		for i, f := range fields {
+			n, err := parse(f)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q at line #%d. %s", name, f, line, err)
+			}
+			row[i] = n
+		}
+		if len(numbers) != 0 && len(row) != len(numbers[0]) {
+			return nil, fmt.Errorf("%ss width = %d, especified by the first row, is different at line #%d (%d)", name, len(numbers[0]), line, len(row))
+		}
+		numbers = append(numbers, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return numbers, nil
+}
+
+// valuesRange returns the lowest and highest values not ignored
+func valuesRange(values [][]float64) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, row := range values {
+		for _, v := range row {
+			if !math.IsNaN(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+	if lo > hi {
+		return 0, 0
+	}
+	return lo, hi
+}
+
+// setValues sets the values of the matrix, the range of the values and the
+// content with the level of every value in the range. The samples and
+// transformations of a matrix keep its range, so the values are normalized
+// the same way
+func (m *Matrix) setValues(values [][]float64, lo, hi float64) {
+	m.values = values
+	m.lo, m.hi = lo, hi
+
+	span := m.hi - m.lo
+	if span == 0 {
+		span = 1
+	}
+	content := make([][]int, len(values))
+	for y, row := range values {
+		content[y] = make([]int, len(row))
+		for x, v := range row {
+			if math.IsNaN(v) {
+				content[y][x] = Ignored
+				continue
+			}
+			content[y][x] = int(math.Round((v - m.lo) / span * (valueLevels - 1)))
+		}
+	}
+	maxX := 0
+	if len(content) != 0 {
+		maxX = len(content[0])
+	}
+	m.setContent(content, maxX, len(content), valueLevels)
+}
+
+// levelValue returns the numeric value of the given level in the range of the
+// values, or `NaN` if it's ignored
+func (m *Matrix) levelValue(level int) float64 {
+	if level == Ignored {
+		return math.NaN()
+	}
+	return m.lo + (m.hi-m.lo)*float64(level)/(valueLevels-1)
+}
+
+// Values returns the numeric value of every cell of the matrix, `NaN` if it's
+// ignored, or nil if the cells are not numeric
+func (m *Matrix) Values() [][]float64 {
+	return m.values
+}
+
+// value returns the value of the cell (x,y), the numeric value or the level
+func (m *Matrix) value(x, y int) float64 {
+	if m.values != nil {
+		return m.values[y][x]
+	}
+	return float64(m.Cell(x, y))
+}
+
+// valueRange returns the lowest and highest values of the cells, the numeric
+// values or the levels
+func (m *Matrix) valueRange() (float64, float64) {
+	if m.values != nil {
+		return m.lo, m.hi
+	}
+	return 0, float64(m.Levels() - 1)
+}
+
+// equalValues returns true if both matrixes have the same numeric values, if
+// any, with the same cells ignored
+func (m *Matrix) equalValues(m1 *Matrix) bool {
+	if (m.values == nil) != (m1.values == nil) || len(m.values) != len(m1.values) {
+		return false
+	}
+	for y, row := range m.values {
+		if len(row) != len(m1.values[y]) {
+			return false
+		}
+		for x, v := range row {
+			v1 := m1.values[y][x]
+			if v != v1 && !(math.IsNaN(v) && math.IsNaN(v1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SprintfValues returns a string representing a matrix of numeric values, a
+// row per line with the values separated by a space and the given `ignore`
+// string to represent the ignored cells
+func (m *Matrix) SprintfValues(ignore string) string {
+	var b bytes.Buffer
+	for y := range m.bits {
+		for x := 0; x < m.maxX; x++ {
+			if x != 0 {
+				b.WriteString(" ")
+			}
+			if m.Cell(x, y) == Ignored {
+				b.WriteString(ignore)
+				continue
+			}
+			b.WriteString(strconv.FormatFloat(m.value(x, y), 'g', -1, 64))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,162 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMatrix_LoadValues(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name        string
+		content     string
+		wantValues  [][]float64
+		wantContent [][]int
+		wantErr     bool
+	}{
+		{"spaces", "0 10\n20 30\n", [][]float64{{0, 10}, {20, 30}}, [][]int{{0, 85}, {170, 255}}, false},
+		{"commas and empty lines", "0.5, 1\n\n1,0.5\n", [][]float64{{0.5, 1}, {1, 0.5}}, [][]int{{0, 255}, {255, 0}}, false},
+		{"ignored", "? 4\n2 ?\n", [][]float64{{nan, 4}, {2, nan}}, [][]int{{Ignored, 255}, {0, Ignored}}, false},
+		{"same value", "7 7\n", [][]float64{{7, 7}}, [][]int{{0, 0}}, false},
+		{"invalid value", "1 x\n2 3\n", nil, nil, true},
+		{"irregular", "1 2\n3\n", nil, nil, true},
+		{"infinite", "1 +Inf\n", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := LoadMatrixValues(bytes.NewBufferString(tt.content), '?')
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMatrixValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want, _ := NewValuesMatrix(tt.wantValues)
+			if !m.equalValues(want) {
+				t.Errorf("LoadMatrixValues() values = %v, want %v", m.Values(), tt.wantValues)
+			}
+			if !reflect.DeepEqual(m.Content(), tt.wantContent) {
+				t.Errorf("LoadMatrixValues() content = %v, want %v", m.Content(), tt.wantContent)
+			}
+		})
+	}
+
+	m, _ := LoadMatrixValues(bytes.NewBufferString("0 2.5\n? 10\n"), '?')
+	if got, want := m.SprintfValues("?"), "0 2.5\n? 10\n"; got != want {
+		t.Errorf("Matrix.SprintfValues() = %q, want %q", got, want)
+	}
+}
+
+func TestMatrix_values_transformations(t *testing.T) {
+	m, _ := NewValuesMatrix([][]float64{
+		{1, 3, 10, 20},
+		{5, 7, math.NaN(), 30},
+	})
+	tests := []struct {
+		name string
+		got  *Matrix
+		want [][]float64
+	}{
+		{"sample", m.Sample(1, 0, 2, 2), [][]float64{{3, 10}, {7, math.NaN()}}},
+		{"transform", m.Transform(Rotate90), [][]float64{{5, 1}, {7, 3}, {math.NaN(), 10}, {30, 20}}},
+		{"resize", m.Resize(2, 1), [][]float64{{4, 20}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the content is the level in the range of the values of m
+			want, _ := NewValuesMatrix(tt.want)
+			if !tt.got.equalValues(want) {
+				t.Errorf("Matrix values = %v, want %v", tt.got.Values(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMatrix_ConfusionWith_values(t *testing.T) {
+	source, _ := NewValuesMatrix([][]float64{{0, 100, 52, 40}})
+	target, _ := NewValuesMatrix([][]float64{{4, 100, 50, 60}})
+	tests := []struct {
+		name   string
+		params SearchParams
+		want   Confusion
+	}{
+		{"exact", SearchParams{}, Confusion{TP: 1, FP: 1, FN: 2}},
+		{"tolerance", SearchParams{Comparison: CompareTolerance, Tolerance: 5}, Confusion{TP: 2, TN: 1, FN: 1}},
+		{"distance", SearchParams{Comparison: CompareDistance}, Confusion{TP: 1.9, TN: 1.84, FP: 0.02, FN: 0.24}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := source.ConfusionWith(target, tt.params)
+			if err != nil {
+				t.Fatalf("Matrix.ConfusionWith() error = %v", err)
+			}
+			if !equalConfusion(got, tt.want) {
+				t.Errorf("Matrix.ConfusionWith() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder2D_Search_values(t *testing.T) {
+	// the target is at (1,1) with some noise, and darker at (4,0)
+	source := "200 200 200 200 80 130\n" +
+		"200 102 148 200 130 180\n" +
+		"200 151 199 200 200 200\n"
+	target := "100 150\n150 200\n"
+	tests := []struct {
+		name       string
+		comparison Comparison
+		percentage float64
+		want       []Match
+	}{
+		{"exact", CompareExact, 100, []Match{}},
+		{"tolerance", CompareTolerance, 100, []Match{{X: 1, Y: 1, Percentage: 100, Width: 2, Height: 2}}},
+		{"distance", CompareDistance, 80, []Match{{X: 4, Y: 0, Percentage: 100 - 8000.0/4/120, Width: 2, Height: 2}, {X: 1, Y: 1, Percentage: 100 - 600.0/4/120, Width: 2, Height: 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(0, 0, tt.percentage, 1, WithValues())
+			if err := f.LoadSource(bytes.NewBufferString(source)); err != nil {
+				t.Fatalf("Finder2D.LoadSource() error = %v", err)
+			}
+			if err := f.LoadTarget(bytes.NewBufferString(target)); err != nil {
+				t.Fatalf("Finder2D.LoadTarget() error = %v", err)
+			}
+			if got := f.Sprintf(f.Target); got != target {
+				t.Errorf("Finder2D.Sprintf() = %q, want %q", got, target)
+			}
+			f.Comparison = tt.comparison
+			f.Tolerance = 2
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			if len(f.Matches) != len(tt.want) {
+				t.Fatalf("Finder2D.Search() = %v, want %v", f.Matches, tt.want)
+			}
+			for i, m := range f.Matches {
+				want := tt.want[i]
+				if m.X != want.X || m.Y != want.Y || math.Abs(m.Percentage-want.Percentage) > 1e-9 {
+					t.Errorf("Finder2D.Search() match #%d = %v, want %v", i, m, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// weightClass is the packed rows of the cells with the same weight
+type weightClass struct {
+	weight float64
+	mask   []bitRow
+}
+
+// SetWeights sets the weight of every cell of the matrix, used when the matrix
+// is the target to compare with. The weights have to be the same size as the
+// matrix and not negative, nil removes the weights so every cell weights 1
+func (m *Matrix) SetWeights(weights [][]float64) error {
+	if weights == nil {
+		m.weights, m.classes = nil, nil
+		return nil
+	}
+	if len(weights) != m.maxY {
+		return fmt.Errorf("weights height = %d is different to the matrix height (%d)", len(weights), m.maxY)
+	}
+	for y, row := range weights {
+		if len(row) != m.maxX {
+			return fmt.Errorf("weights width = %d at row #%d is different to the matrix width (%d)", len(row), y, m.maxX)
+		}
+		for x, w := range row {
+			if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+				return fmt.Errorf("invalid weight %v at (%d,%d), it has to be a non negative number", w, x, y)
+			}
+		}
+	}
+	m.weights = weights
+	m.packWeights()
+	return nil
+}
+
+// Weights returns the weight of every cell of the matrix, nil if the cells are
+// not weighted
+func (m *Matrix) Weights() [][]float64 {
+	return m.weights
+}
+
+// packWeights packs the cells with the same weight, sorted by weight. The
+// cells with weight zero are not included, they don't count
+func (m *Matrix) packWeights() {
+	masks := map[float64][]bitRow{}
+	for y, row := range m.weights {
+		for x, w := range row {
+			if w == 0 {
+				continue
+			}
+			mask, ok := masks[w]
+			if !ok {
+				mask = make([]bitRow, m.maxY)
+				for i := range mask {
+					mask[i] = make(bitRow, (m.maxX+wordSize-1)/wordSize)
+				}
+				masks[w] = mask
+			}
+			mask[y][x/wordSize] |= 1 << uint(x%wordSize)
+		}
+	}
+
+	m.classes = make([]weightClass, 0, len(masks))
+	for w, mask := range masks {
+		m.classes = append(m.classes, weightClass{w, mask})
+	}
+	sort.Slice(m.classes, func(i, j int) bool {
+		return m.classes[i].weight < m.classes[j].weight
+	})
+}
+
+// weight returns the weight of the cell (x,y)
+func (m *Matrix) weight(x, y int) float64 {
+	if m.weights == nil {
+		return 1
+	}
+	return m.weights[y][x]
+}
+
+// LoadWeights loads a weights matrix from a reader. Every line is a row with
+// the weights separated by spaces, tabs or commas. The empty lines are ignored
+func LoadWeights(r io.Reader) ([][]float64, error) {
+	return readNumbers(r, "weight", func(field string) (float64, error) {
+		return strconv.ParseFloat(field, 64)
+	})
+}
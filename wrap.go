@@ -0,0 +1,135 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import "image"
+
+// wrap returns a copy of the matrix with `dx` more columns at the right and
+// `dy` more rows at the bottom, copies of the first columns and rows of the
+// matrix as if it were a torus
+func (m *Matrix) wrap(dx, dy int) *Matrix {
+	w, h := m.maxX+dx, m.maxY+dy
+	content := make([][]int, h)
+	for y := range content {
+		content[y] = make([]int, w)
+		for x := range content[y] {
+			content[y][x] = m.Cell(x%m.maxX, y%m.maxY)
+		}
+	}
+
+	wm := &Matrix{}
+	wm.setContent(content, w, h, m.levels)
+	if m.values != nil {
+		values := make([][]float64, h)
+		for y := range values {
+			values[y] = make([]float64, w)
+			for x := range values[y] {
+				values[y][x] = m.values[y%m.maxY][x%m.maxX]
+			}
+		}
+		wm.setValues(values, m.lo, m.hi)
+	}
+	return wm
+}
+
+// mod returns the modulus of a by b, from 0 to b-1 even if a is negative. It's
+// a if b is not positive
+func mod(a, b int) int {
+	if b <= 0 {
+		return a
+	}
+	if a %= b; a < 0 {
+		a += b
+	}
+	return a
+}
+
+// matchBoxes returns the area of the match in cells. With `Wrap` the area of a
+// match crossing the right or bottom border of the source is split in up to
+// four boxes, the box of the match and its copies moved to the left and the
+// top of the source, the parts out of the source are not clipped
+func (f *Finder2D) matchBoxes(m Match) []image.Rectangle {
+	w, h := f.MatchSize(m)
+	return f.torus().boxes(image.Rect(m.X, m.Y, m.X+w, m.Y+h))
+}
+
+// torus is the size of a wrapped source, where the areas crossing the right or
+// bottom border continue at the left or the top. The zero torus doesn't wrap
+type torus struct{ w, h int }
+
+// torus returns the torus of the source with `Wrap`, else the zero torus
+func (f *Finder2D) torus() torus {
+	if !f.Wrap || f.Source == nil {
+		return torus{}
+	}
+	w, h := f.Source.Size()
+	return torus{w, h}
+}
+
+// wraps returns true if the torus isn't the zero torus
+func (t torus) wraps() bool {
+	return t.w > 0 && t.h > 0
+}
+
+// boxes returns the box and, on a torus, its copies moved to the left and the
+// top of the torus that overlap it
+func (t torus) boxes(box image.Rectangle) []image.Rectangle {
+	boxes := []image.Rectangle{box}
+	if !t.wraps() {
+		return boxes
+	}
+	bounds := image.Rect(0, 0, t.w, t.h)
+	for _, d := range []image.Point{{-t.w, 0}, {0, -t.h}, {-t.w, -t.h}} {
+		if b := box.Add(d); b.Overlaps(bounds) {
+			boxes = append(boxes, b)
+		}
+	}
+	return boxes
+}
+
+// intersection returns the area of the intersection of both matches. On a
+// torus it's the sum of the intersections of the pieces of the matches in it
+func (t torus) intersection(m, m1 Match) int {
+	box := image.Rect(m.X, m.Y, m.X+m.Width, m.Y+m.Height)
+	box1 := image.Rect(m1.X, m1.Y, m1.X+m1.Width, m1.Y+m1.Height)
+	if !t.wraps() {
+		r := box.Intersect(box1)
+		return r.Dx() * r.Dy()
+	}
+	bounds := image.Rect(0, 0, t.w, t.h)
+	var area int
+	for _, b := range t.boxes(box) {
+		for _, b1 := range t.boxes(box1) {
+			r := b.Intersect(b1).Intersect(bounds)
+			area += r.Dx() * r.Dy()
+		}
+	}
+	return area
+}
+
+// offset returns the difference b-a of two coordinates. On a torus of the
+// given size it's the shortest way around, from -size/2 to size/2
+func offset(a, b, size int) int {
+	if size <= 0 {
+		return b - a
+	}
+	d := mod(b-a, size)
+	if d > size/2 {
+		d -= size
+	}
+	return d
+}
@@ -0,0 +1,153 @@
+/*
+Copyright The Finder2D Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package finder2d
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFinder2D_Search_wrap(t *testing.T) {
+	f := loadTestFinder(t, 99, 1)
+	// the target crosses the right and bottom borders at (30,20)
+	content := make([][]int, 30)
+	for y := range content {
+		content[y] = make([]int, 40)
+	}
+	for ty, row := range f.Target.Content() {
+		for tx, v := range row {
+			content[(20+ty)%30][(30+tx)%40] = v
+		}
+	}
+	f.Source = newTestMatrix(t, content)
+
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if len(f.Matches) != 0 {
+		t.Fatalf("Finder2D.Search() without wrap = %v, want no matches", f.Matches)
+	}
+
+	want := []Match{{X: 30, Y: 20, Percentage: 100}}
+	f.Wrap = true
+	for _, strategy := range Searchers() {
+		t.Run(strategy, func(t *testing.T) {
+			f.Strategy = strategy
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			if !reflect.DeepEqual(f.Matches, want) {
+				t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want)
+			}
+		})
+	}
+
+	// the match area continues at the left and top borders
+	for _, p := range []struct {
+		x, y int
+		want bool
+	}{{30, 20, true}, {39, 29, true}, {0, 0, true}, {4, 4, true}, {5, 4, false}, {4, 19, false}, {29, 20, false}} {
+		if got := f.IsInMatchArea(p.x, p.y); got != p.want {
+			t.Errorf("Finder2D.IsInMatchArea(%d,%d) = %v, want %v", p.x, p.y, got, p.want)
+		}
+	}
+	out := f.Matrix()
+	if matched := strings.Count(out, unoMatch) + strings.Count(out, ceroMatch); matched != 15*15 {
+		t.Errorf("Finder2D.Matrix() has %d cells in the match area, want %d", matched, 15*15)
+	}
+	if boxes := f.matchBoxes(f.Matches[0]); len(boxes) != 4 {
+		t.Errorf("Finder2D.matchBoxes() = %v, want 4 boxes", boxes)
+	}
+	var b bytes.Buffer
+	if err := f.EncodeReport(&b); err != nil {
+		t.Fatalf("Finder2D.EncodeReport() error = %v", err)
+	}
+	if want := `<rect id="match-0-2" class="match" x="-10" y="-10" width="15" height="15">`; !strings.Contains(b.String(), want) {
+		t.Errorf("Finder2D.EncodeReport() does not contain %q", want)
+	}
+
+	f.Border = true
+	if err := f.Search(); err == nil {
+		t.Errorf("Finder2D.Search() with border and wrap error = nil, want an error")
+	}
+}
+
+func TestFinder2D_Search_wrap_seam(t *testing.T) {
+	// a block of 4x4 ones straddles the right border at (9,2), the positions
+	// around it score 75% on both sides of the border
+	content := make([][]int, 8)
+	for y := range content {
+		content[y] = make([]int, 10)
+	}
+	for y := 2; y < 6; y++ {
+		for _, x := range []int{9, 0, 1, 2} {
+			content[y][x] = 1
+		}
+	}
+	target := [][]int{{1, 1, 1, 1}, {1, 1, 1, 1}, {1, 1, 1, 1}, {1, 1, 1, 1}}
+
+	f := New(DefaultOne, DefaultZero, 70, 1)
+	f.Source = newTestMatrix(t, content)
+	f.Target = newTestMatrix(t, target)
+	f.Wrap = true
+
+	want := []Match{{X: 9, Y: 2, Percentage: 100}}
+	for _, reduction := range Reductions {
+		t.Run(reduction.String(), func(t *testing.T) {
+			f.Reduction = reduction
+			if err := f.Search(); err != nil {
+				t.Fatalf("Finder2D.Search() error = %v", err)
+			}
+			if !reflect.DeepEqual(f.Matches, want) {
+				t.Errorf("Finder2D.Search() = %v, want %v", f.Matches, want)
+			}
+		})
+	}
+
+	// the p-value compares the 16 cells of the match, a fifth of the source
+	// cells are ones
+	f.Significance = true
+	if err := f.Search(); err != nil {
+		t.Fatalf("Finder2D.Search() error = %v", err)
+	}
+	if len(f.Matches) != 1 || math.Abs(f.Matches[0].PValue/math.Pow(0.2, 16)-1) > 1e-9 {
+		t.Errorf("Finder2D.Search() with significance = %v, want a p-value of %v", f.Matches, math.Pow(0.2, 16))
+	}
+}
+
+func Test_mod(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b, want int
+	}{
+		{"lower", 3, 5, 3},
+		{"higher", 7, 5, 2},
+		{"negative", -1, 5, 4},
+		{"negative multiple", -10, 5, 0},
+		{"no modulus", 3, 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mod(tt.a, tt.b); got != tt.want {
+				t.Errorf("mod(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}